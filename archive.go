@@ -0,0 +1,122 @@
+package itermore
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// TarEntries creates a sequence, which yields headers and contents of entries from the given tar stream.
+// The content reader is valid until the next iteration: unread rest of the entry is skipped
+// when the next entry is requested.
+//
+// The returned function reports the error, which stopped the last iteration.
+// Errors of reading entry contents are returned by the content reader.
+func TarEntries(re io.Reader) (iter.Seq2[*tar.Header, io.Reader], func() error) {
+	var tarErr error
+
+	seq := func(yield func(*tar.Header, io.Reader) bool) {
+		tarErr = nil
+		tr := tar.NewReader(re)
+
+		for {
+			header, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				tarErr = err
+				return
+			}
+
+			if !yield(header, tr) {
+				return
+			}
+		}
+	}
+
+	return seq, func() error { return tarErr }
+}
+
+// ZipEntries creates a sequence, which yields headers and contents of files from the given zip archive.
+// The content reader is valid until the next iteration, it is closed when the next file is requested.
+// Checksums are verified only for fully read files.
+//
+// The returned function reports the error, which stopped the last iteration.
+// Errors of reading file contents are returned by the content reader.
+func ZipEntries(zr *zip.Reader) (iter.Seq2[*zip.FileHeader, io.Reader], func() error) {
+	var zipErr error
+
+	seq := func(yield func(*zip.FileHeader, io.Reader) bool) {
+		zipErr = nil
+
+		for _, file := range zr.File {
+			content, err := file.Open()
+			if err != nil {
+				zipErr = fmt.Errorf("opening %s: %w", file.Name, err)
+				return
+			}
+
+			ok := yield(&file.FileHeader, content)
+			_ = content.Close()
+
+			if !ok {
+				return
+			}
+		}
+	}
+
+	return seq, func() error { return zipErr }
+}
+
+// WriteTar writes entries from the given sequence to the writer as a tar archive.
+// Contents are streamed from the readers without buffering, they must provide exactly header.Size bytes.
+// Reader may be nil for entries without content, like directories or links.
+// It returns the first error encountered.
+func WriteTar(wr io.Writer, seq iter.Seq2[*tar.Header, io.Reader]) error {
+	tw := tar.NewWriter(wr)
+
+	for header, content := range seq {
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("writing header of %s: %w", header.Name, err)
+		}
+
+		if content == nil {
+			continue
+		}
+
+		if _, err := io.Copy(tw, content); err != nil {
+			return fmt.Errorf("writing %s: %w", header.Name, err)
+		}
+	}
+
+	return tw.Close()
+}
+
+// WriteZip writes files from the given sequence to the writer as a zip archive.
+// Contents are streamed from the readers without buffering and compressed according to header.Method.
+// Reader may be nil for entries without content, like directories.
+// It returns the first error encountered.
+func WriteZip(wr io.Writer, seq iter.Seq2[*zip.FileHeader, io.Reader]) error {
+	zw := zip.NewWriter(wr)
+
+	for header, content := range seq {
+		fw, err := zw.CreateHeader(header)
+		if err != nil {
+			return fmt.Errorf("writing header of %s: %w", header.Name, err)
+		}
+
+		if content == nil {
+			continue
+		}
+
+		if _, err := io.Copy(fw, content); err != nil {
+			return fmt.Errorf("writing %s: %w", header.Name, err)
+		}
+	}
+
+	return zw.Close()
+}
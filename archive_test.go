@@ -0,0 +1,271 @@
+package itermore_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ninedraft/itermore"
+)
+
+func ExampleTarEntries() {
+	files := func(yield func(*tar.Header, io.Reader) bool) {
+		for _, name := range []string{"a.txt", "b.txt"} {
+			content := "content of " + name
+			header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}
+
+			if !yield(header, strings.NewReader(content)) {
+				return
+			}
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	if err := itermore.WriteTar(buf, files); err != nil {
+		panic(err)
+	}
+
+	entries, tarErr := itermore.TarEntries(buf)
+	for header, content := range entries {
+		data, _ := io.ReadAll(content)
+		fmt.Printf("%s: %s\n", header.Name, data)
+	}
+
+	if err := tarErr(); err != nil {
+		panic(err)
+	}
+	// Output: a.txt: content of a.txt
+	// b.txt: content of b.txt
+}
+
+var archiveFiles = []itermore.Pair[string, string]{
+	{A: "dir/", B: ""},
+	{A: "dir/a.txt", B: "hello"},
+	{A: "dir/b.txt", B: strings.Repeat("large file ", 10_000)},
+	{A: "c.txt", B: ""},
+}
+
+func tarFiles(files []itermore.Pair[string, string]) iter.Seq2[*tar.Header, io.Reader] {
+	return func(yield func(*tar.Header, io.Reader) bool) {
+		for _, file := range files {
+			header := &tar.Header{Name: file.A, Mode: 0o644, Size: int64(len(file.B))}
+			var content io.Reader = strings.NewReader(file.B)
+
+			if strings.HasSuffix(file.A, "/") {
+				header.Typeflag, header.Mode = tar.TypeDir, 0o755
+				content = nil
+			}
+
+			if !yield(header, content) {
+				return
+			}
+		}
+	}
+}
+
+func zipFiles(files []itermore.Pair[string, string]) iter.Seq2[*zip.FileHeader, io.Reader] {
+	return func(yield func(*zip.FileHeader, io.Reader) bool) {
+		for _, file := range files {
+			header := &zip.FileHeader{Name: file.A, Method: zip.Deflate}
+			var content io.Reader = strings.NewReader(file.B)
+
+			if strings.HasSuffix(file.A, "/") {
+				header.Method = zip.Store
+				content = nil
+			}
+
+			if !yield(header, content) {
+				return
+			}
+		}
+	}
+}
+
+// readEntries reads all entries, skipping contents of every second entry.
+func readEntries[H any](seq iter.Seq2[H, io.Reader], name func(H) string) ([]itermore.Pair[string, string], error) {
+	var got []itermore.Pair[string, string]
+	i := 0
+	for header, content := range seq {
+		i++
+		if i%2 == 0 {
+			got = append(got, itermore.PairOf(name(header), "<skipped>"))
+			continue
+		}
+
+		data, err := io.ReadAll(content)
+		if err != nil {
+			return got, err
+		}
+		got = append(got, itermore.PairOf(name(header), string(data)))
+	}
+
+	return got, nil
+}
+
+func skippedFiles(files []itermore.Pair[string, string]) []itermore.Pair[string, string] {
+	want := slices.Clone(files)
+	for i := 1; i < len(want); i += 2 {
+		want[i].B = "<skipped>"
+	}
+
+	return want
+}
+
+func TestTarEntries(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	if err := itermore.WriteTar(buf, tarFiles(archiveFiles)); err != nil {
+		t.Fatal(err)
+	}
+	archive := buf.Bytes()
+
+	seq, _ := itermore.TarEntries(bytes.NewReader(archive))
+	assertBreak2(t, seq)
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		seq, tarErr := itermore.TarEntries(bytes.NewReader(archive))
+		got, err := readEntries(seq, func(h *tar.Header) string { return h.Name })
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := tarErr(); err != nil {
+			t.Fatal(err)
+		}
+
+		if want := skippedFiles(archiveFiles); !slices.Equal(got, want) {
+			t.Errorf("got:  %.40q", got)
+			t.Errorf("want: %.40q", want)
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		t.Parallel()
+
+		// cut the archive in the middle of the second header
+		seq, tarErr := itermore.TarEntries(bytes.NewReader(archive[:512+100]))
+		for range seq {
+		}
+
+		if err := tarErr(); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("got %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+	})
+}
+
+func TestWriteTar(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	t.Run("reader-error", func(t *testing.T) {
+		t.Parallel()
+
+		files := itermore.Zip(
+			itermore.Items(&tar.Header{Name: "a.txt", Size: 10}),
+			itermore.Items[io.Reader](&errReader{err: errTest}))
+
+		if err := itermore.WriteTar(io.Discard, files); !errors.Is(err, errTest) {
+			t.Errorf("got %v, want %v", err, errTest)
+		}
+	})
+
+	t.Run("short-content", func(t *testing.T) {
+		t.Parallel()
+
+		files := itermore.Zip(
+			itermore.Items(&tar.Header{Name: "a.txt", Size: 10}),
+			itermore.Items[io.Reader](strings.NewReader("short")))
+
+		if err := itermore.WriteTar(io.Discard, files); err == nil {
+			t.Error("expected error for short content")
+		}
+	})
+}
+
+func TestZipEntries(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	if err := itermore.WriteZip(buf, zipFiles(archiveFiles)); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seq, _ := itermore.ZipEntries(zr)
+	assertBreak2(t, seq)
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		seq, zipErr := itermore.ZipEntries(zr)
+		got, err := readEntries(seq, func(h *zip.FileHeader) string { return h.Name })
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := zipErr(); err != nil {
+			t.Fatal(err)
+		}
+
+		if want := skippedFiles(archiveFiles); !slices.Equal(got, want) {
+			t.Errorf("got:  %.40q", got)
+			t.Errorf("want: %.40q", want)
+		}
+	})
+
+	t.Run("unsupported-method", func(t *testing.T) {
+		t.Parallel()
+
+		buf := &bytes.Buffer{}
+		zw := zip.NewWriter(buf)
+		if _, err := zw.CreateRaw(&zip.FileHeader{Name: "a.txt", Method: 99}); err != nil {
+			t.Fatal(err)
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		seq, zipErr := itermore.ZipEntries(zr)
+		for range seq {
+			t.Error("unexpected entry")
+		}
+
+		if err := zipErr(); !errors.Is(err, zip.ErrAlgorithm) {
+			t.Errorf("got %v, want %v", err, zip.ErrAlgorithm)
+		}
+	})
+}
+
+func TestWriteZip(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	files := itermore.Zip(
+		itermore.Items(&zip.FileHeader{Name: "a.txt"}),
+		itermore.Items[io.Reader](&errReader{err: errTest}))
+
+	if err := itermore.WriteZip(io.Discard, files); !errors.Is(err, errTest) {
+		t.Errorf("got %v, want %v", err, errTest)
+	}
+}
@@ -3,6 +3,8 @@ package itermore
 import (
 	"context"
 	"iter"
+	"strconv"
+	"sync"
 )
 
 // Chan returns a new sequence that iterates over values from the given channel.
@@ -27,20 +29,105 @@ func Chan[E any](ch <-chan E) iter.Seq[E] {
 // Closing channel will stop iteration.
 // If ctx is canceled, ChanCtx will stop iteration.
 func ChanCtx[E any](ctx context.Context, ch <-chan E) iter.Seq[E] {
+	return chanCtx(ctx, ch, nil)
+}
+
+// StopReason describes, why a sequence has stopped.
+type StopReason int
+
+const (
+	// StopNone means that the sequence has not stopped yet.
+	StopNone StopReason = iota
+	// StopClosed means that the source channel is closed.
+	StopClosed
+	// StopCanceled means that the context is canceled.
+	StopCanceled
+	// StopConsumer means that the consumer has stopped the iteration.
+	StopConsumer
+)
+
+func (reason StopReason) String() string {
+	switch reason {
+	case StopNone:
+		return "none"
+	case StopClosed:
+		return "closed"
+	case StopCanceled:
+		return "canceled"
+	case StopConsumer:
+		return "consumer stop"
+	default:
+		return "StopReason(" + strconv.Itoa(int(reason)) + ")"
+	}
+}
+
+// StopStatus reports, why the last iteration of a sequence has stopped.
+// It is safe for concurrent use.
+type StopStatus struct {
+	mu     sync.Mutex
+	reason StopReason
+	err    error
+}
+
+// Reason returns the reason of the last stop, or StopNone if the sequence has not stopped yet.
+func (status *StopStatus) Reason() StopReason {
+	status.mu.Lock()
+	defer status.mu.Unlock()
+
+	return status.reason
+}
+
+// Err returns the context error, if the sequence is stopped due to context cancellation, and nil otherwise.
+// It allows to distinguish graceful shutdown from cancellation.
+func (status *StopStatus) Err() error {
+	status.mu.Lock()
+	defer status.mu.Unlock()
+
+	return status.err
+}
+
+func (status *StopStatus) set(reason StopReason, err error) {
+	if status == nil {
+		return
+	}
+
+	status.mu.Lock()
+	defer status.mu.Unlock()
+
+	status.reason, status.err = reason, err
+}
+
+// ChanCtxStatus is like ChanCtx, but it also returns a status, which reports why the iteration has stopped:
+// the channel is closed, ctx is canceled or the consumer has stopped.
+// The status is updated each time the iteration stops.
+func ChanCtxStatus[E any](ctx context.Context, ch <-chan E) (iter.Seq[E], *StopStatus) {
+	status := &StopStatus{}
+
+	return chanCtx(ctx, ch, status), status
+}
+
+// chanCtx implements ChanCtx, reporting the stop reason to status, if it is not nil.
+func chanCtx[E any](ctx context.Context, ch <-chan E, status *StopStatus) iter.Seq[E] {
 	return func(yield func(E) bool) {
+		status.set(StopNone, nil)
+
 		if ch == nil {
+			status.set(StopClosed, nil)
 			return
 		}
 
 		for {
 			select {
 			case <-ctx.Done():
+				status.set(StopCanceled, ctx.Err())
 				return
 			case value, ok := <-ch:
 				if !ok {
+					status.set(StopClosed, nil)
 					return
 				}
 				if !yield(value) {
+					status.set(StopConsumer, nil)
 					return
 				}
 			}
@@ -64,3 +151,123 @@ func CollectChanCtx[E any](ctx context.Context, ch chan<- E, seq iter.Seq[E]) {
 		}
 	}
 }
+
+// Buffered returns a sequence, which reads up to size values ahead from seq in a separate goroutine.
+// It is useful to decouple a slow producer from a slow consumer.
+// If size is negative or zero, values are handed over one by one.
+//
+// If seq panics, the panic is propagated to the consumer as *PanicError.
+// Stopping the returned sequence waits for seq to return.
+func Buffered[E any](seq iter.Seq[E], size int) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		values := make(chan E, max(size, 0))
+		done := make(chan struct{})
+		panicked := make(chan *PanicError, 1)
+
+		go func() {
+			defer close(values)
+			defer func() {
+				if p := recover(); p != nil {
+					panicked <- newPanicError(p)
+				}
+			}()
+
+			for value := range seq {
+				select {
+				case <-done:
+					return
+				case values <- value:
+					// pass
+				}
+			}
+		}()
+
+		defer func() {
+			close(done)
+			Drain(Chan(values))
+		}()
+
+		for value := range values {
+			if !yield(value) {
+				return
+			}
+		}
+
+		select {
+		case pe := <-panicked:
+			panic(pe)
+		default:
+		}
+	}
+}
+
+// Producer is a handle of the goroutine started by ToChan.
+type Producer struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+	errs   chan error
+	err    error
+}
+
+// ToChan starts a goroutine, which sends values from seq to the returned channel with the given buffer size.
+// The channel is closed, when seq ends, ctx is canceled or the producer is canceled.
+// It is a non-blocking counterpart of CollectChanCtx, which allows to use sequences in select statements.
+//
+// The consumer must read the channel until it is closed or cancel the producer, otherwise the goroutine leaks.
+// If seq panics, the panic is recovered and reported by the producer as *PanicError.
+func ToChan[E any](ctx context.Context, seq iter.Seq[E], buffer int) (<-chan E, *Producer) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	values := make(chan E, max(buffer, 0))
+	producer := &Producer{
+		cancel: cancel,
+		done:   make(chan struct{}),
+		errs:   make(chan error, 1),
+	}
+
+	go func() {
+		defer close(producer.done)
+		defer close(producer.errs)
+		defer cancel()
+		defer close(values)
+
+		defer func() {
+			if p := recover(); p != nil {
+				pe := newPanicError(p)
+				producer.err = pe
+				producer.errs <- pe
+			}
+		}()
+
+		for value := range seq {
+			select {
+			case <-ctx.Done():
+				producer.err = ctx.Err()
+				return
+			case values <- value:
+				// pass
+			}
+		}
+	}()
+
+	return values, producer
+}
+
+// Cancel stops the producer. It doesn't wait for the producer goroutine to exit.
+func (p *Producer) Cancel() {
+	p.cancel()
+}
+
+// Wait waits for the producer goroutine to exit and reports, why it has stopped.
+// It returns nil, if the sequence has ended, ctx error, if the producer is canceled,
+// and *PanicError, if the sequence has panicked.
+func (p *Producer) Wait() error {
+	<-p.done
+	return p.err
+}
+
+// Errors returns a channel, which receives *PanicError, if the sequence panics.
+// The channel is closed, when the producer goroutine exits.
+func (p *Producer) Errors() <-chan error {
+	return p.errs
+}
@@ -2,9 +2,11 @@ package itermore_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/ninedraft/itermore"
 )
@@ -217,3 +219,258 @@ func TestCollectChanCtx(t *testing.T) {
 		}
 	})
 }
+
+func TestBuffered(t *testing.T) {
+	t.Parallel()
+
+	assertBreak(t, itermore.Buffered(itermore.Items(1, 2, 3), 2))
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		want := []int{1, 2, 3, 4, 5}
+		for _, size := range []int{-1, 0, 1, 10} {
+			got := slices.Collect(itermore.Buffered(itermore.Slice(want), size))
+			if !slices.Equal(got, want) {
+				t.Errorf("size %d got:  %v", size, got)
+				t.Errorf("size %d want: %v", size, want)
+			}
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		t.Parallel()
+
+		stopped := false
+		seq := itermore.Then(itermore.Forever(1), func() { stopped = true })
+
+		for range itermore.Buffered(seq, 4) {
+			break
+		}
+
+		if !stopped {
+			t.Fatal("source must be stopped before Buffered returns")
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		t.Parallel()
+
+		errTest := errors.New("test error")
+		seq := func(yield func(int) bool) {
+			yield(1)
+			panic(errTest)
+		}
+
+		defer func() {
+			p := recover()
+			pe, ok := p.(*itermore.PanicError)
+			if !ok {
+				t.Fatalf("got %T panic, want *itermore.PanicError", p)
+			}
+			if !errors.Is(pe, errTest) {
+				t.Fatalf("got %v, want %v", pe.Value, errTest)
+			}
+		}()
+
+		itermore.Drain(itermore.Buffered(seq, 1))
+	})
+}
+
+func ExampleToChan() {
+	ctx := context.Background()
+	values, producer := itermore.ToChan(ctx, itermore.Items(1, 2, 3), 0)
+	defer producer.Cancel()
+
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case value, ok := <-values:
+			if !ok {
+				fmt.Println("done:", producer.Wait())
+				return
+			}
+			fmt.Println(value)
+		case <-timeout:
+			fmt.Println("timeout")
+			return
+		}
+	}
+	// Output: 1
+	// 2
+	// 3
+	// done: <nil>
+}
+
+func TestToChan(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		want := []int{1, 2, 3, 4}
+		values, producer := itermore.ToChan(ctx, itermore.Slice(want), 2)
+
+		if got := slices.Collect(itermore.Chan(values)); !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+
+		if err := producer.Wait(); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, ok := <-producer.Errors(); ok {
+			t.Error("unexpected error")
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+
+		values, producer := itermore.ToChan(ctx, itermore.Forever(1), 0)
+		<-values
+		producer.Cancel()
+
+		if err := producer.Wait(); !errors.Is(err, context.Canceled) {
+			t.Errorf("got %v, want %v", err, context.Canceled)
+		}
+
+		// the channel is closed after the producer exits
+		itermore.Drain(itermore.Chan(values))
+	})
+
+	t.Run("ctx", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		values, producer := itermore.ToChan(ctx, itermore.Forever(1), 0)
+		<-values
+
+		if err := producer.Wait(); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		t.Parallel()
+
+		errTest := errors.New("test error")
+		seq := func(yield func(int) bool) {
+			yield(1)
+			panic(errTest)
+		}
+
+		values, producer := itermore.ToChan(ctx, seq, 1)
+
+		var pe *itermore.PanicError
+		if err := <-producer.Errors(); !errors.As(err, &pe) || !errors.Is(err, errTest) {
+			t.Errorf("got %v, want panic error", err)
+		}
+
+		if got := slices.Collect(itermore.Chan(values)); !slices.Equal(got, []int{1}) {
+			t.Errorf("got %v, want [1]", got)
+		}
+
+		if err := producer.Wait(); !errors.Is(err, errTest) {
+			t.Errorf("got %v, want %v", err, errTest)
+		}
+	})
+}
+
+func ExampleChanCtxStatus() {
+	ch := make(chan int, 2)
+	ch <- 1
+	ch <- 2
+	close(ch)
+
+	values, status := itermore.ChanCtxStatus(context.Background(), ch)
+	for value := range values {
+		fmt.Println(value)
+	}
+
+	fmt.Println(status.Reason(), status.Err())
+	// Output: 1
+	// 2
+	// closed <nil>
+}
+
+func TestChanCtxStatus(t *testing.T) {
+	t.Parallel()
+
+	{
+		ch := make(chan int, 1)
+		ch <- 10
+		seq, _ := itermore.ChanCtxStatus(context.Background(), ch)
+		assertBreak(t, seq)
+	}
+
+	t.Run("closed", func(t *testing.T) {
+		t.Parallel()
+
+		ch := make(chan int, 1)
+		ch <- 1
+		close(ch)
+
+		seq, status := itermore.ChanCtxStatus(context.Background(), ch)
+		if status.Reason() != itermore.StopNone {
+			t.Errorf("got %v before iteration, want %v", status.Reason(), itermore.StopNone)
+		}
+
+		if got := slices.Collect(seq); !slices.Equal(got, []int{1}) {
+			t.Errorf("got %v, want [1]", got)
+		}
+
+		if status.Reason() != itermore.StopClosed || status.Err() != nil {
+			t.Errorf("got %v %v, want %v", status.Reason(), status.Err(), itermore.StopClosed)
+		}
+
+		nilSeq, status := itermore.ChanCtxStatus[int](context.Background(), nil)
+		itermore.Drain(nilSeq)
+
+		if status.Reason() != itermore.StopClosed {
+			t.Errorf("nil channel: got %v, want %v", status.Reason(), itermore.StopClosed)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		seq, status := itermore.ChanCtxStatus(ctx, make(chan int))
+		itermore.Drain(seq)
+
+		if status.Reason() != itermore.StopCanceled || !errors.Is(status.Err(), context.Canceled) {
+			t.Errorf("got %v %v, want %v", status.Reason(), status.Err(), itermore.StopCanceled)
+		}
+	})
+
+	t.Run("consumer", func(t *testing.T) {
+		t.Parallel()
+
+		ch := make(chan int, 2)
+		ch <- 1
+		ch <- 2
+
+		seq, status := itermore.ChanCtxStatus(context.Background(), ch)
+		for range seq {
+			break
+		}
+
+		if status.Reason() != itermore.StopConsumer || status.Err() != nil {
+			t.Errorf("got %v %v, want %v", status.Reason(), status.Err(), itermore.StopConsumer)
+		}
+
+		close(ch)
+		itermore.Drain(seq)
+
+		if status.Reason() != itermore.StopClosed {
+			t.Errorf("second iteration: got %v, want %v", status.Reason(), itermore.StopClosed)
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package itermore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// CommandOption configures Command.
+type CommandOption func(*commandConfig)
+
+type commandConfig struct {
+	stdin  iter.Seq[[]byte]
+	frames *FrameCodec
+}
+
+// WithStdin makes Command write chunks from the given sequence to the standard input of the command.
+// Standard input is closed when the sequence ends.
+// After the command exits, Command waits for the sequence to end before reporting the exit status.
+func WithStdin(seq iter.Seq[[]byte]) CommandOption {
+	return func(cfg *commandConfig) {
+		cfg.stdin = seq
+	}
+}
+
+// WithFrames makes Command split the standard output of the command into frames using the codec, instead of lines.
+func WithFrames(codec FrameCodec) CommandOption {
+	return func(cfg *commandConfig) {
+		cfg.frames = &codec
+	}
+}
+
+// Command creates a sequence, which starts the command and yields lines of its standard output.
+// Lines share the underlying buffer and are valid until the next iteration, line endings are not included.
+// The command must not be started and its standard output must not be set.
+// The sequence can be iterated only once.
+//
+// If the consumer stops the iteration early or ctx is canceled, the process is killed.
+//
+// The sequence is error-aware: if the command can't be started, reading of its output fails,
+// or it exits with an error, the error is yielded with a nil line as the last pair.
+// If ctx is canceled, ctx error is yielded instead of the exit error.
+// Errors of writing the standard input are reported only if the command exits successfully.
+func Command(ctx context.Context, cmd *exec.Cmd, opts ...CommandOption) iter.Seq2[[]byte, error] {
+	cfg := &commandConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return func(yield func([]byte, error) bool) {
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		var stdin io.WriteCloser
+		if cfg.stdin != nil {
+			stdin, err = cmd.StdinPipe()
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+
+		if err := cmd.Start(); err != nil {
+			yield(nil, err)
+			return
+		}
+
+		stopKill := context.AfterFunc(ctx, func() { _ = cmd.Process.Kill() })
+		defer stopKill()
+
+		stdinErr := make(chan error, 1)
+		if stdin != nil {
+			go func() {
+				_, err := CollectJoinBytes(stdin, cfg.stdin, nil)
+				stdinErr <- errors.Join(err, stdin.Close())
+			}()
+		} else {
+			stdinErr <- nil
+		}
+
+		var output iter.Seq2[[]byte, error]
+		if cfg.frames != nil {
+			output = Frames(stdout, *cfg.frames)
+		} else {
+			output = LinesBytes(stdout)
+		}
+
+		for line, err := range output {
+			if err != nil {
+				_ = cmd.Process.Kill()
+				_ = cmd.Wait()
+
+				yield(nil, err)
+				return
+			}
+
+			if !yield(line, nil) {
+				_ = cmd.Process.Kill()
+				_ = cmd.Wait()
+				return
+			}
+		}
+
+		err = cmd.Wait()
+		writeErr := <-stdinErr
+
+		switch {
+		case !stopKill():
+			// the process is killed due to ctx cancellation
+			err = ctx.Err()
+		case err == nil && !isClosedPipe(writeErr):
+			err = writeErr
+		}
+
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// isClosedPipe reports whether err is caused by writing to the pipe, which is closed by the reading side.
+func isClosedPipe(err error) bool {
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, os.ErrClosed)
+}
@@ -0,0 +1,185 @@
+package itermore_test
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ninedraft/itermore"
+)
+
+// helperCommand returns a command, which runs TestCommandHelper in the given mode.
+func helperCommand(t *testing.T, mode string) *exec.Cmd {
+	t.Helper()
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestCommandHelper$")
+	// the race detector delays exit of the process by 1s by default
+	cmd.Env = append(os.Environ(), "ITERMORE_HELPER_MODE="+mode, "GORACE=atexit_sleep_ms=0")
+
+	return cmd
+}
+
+// TestCommandHelper is not a real test, it is a helper process for TestCommand.
+func TestCommandHelper(t *testing.T) {
+	mode := os.Getenv("ITERMORE_HELPER_MODE")
+	if mode == "" {
+		t.Skip("helper process")
+	}
+
+	switch mode {
+	case "lines":
+		fmt.Print("first\nsecond\r\nthird")
+	case "upper":
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			fmt.Println(strings.ToUpper(scanner.Text()))
+		}
+	case "fail":
+		fmt.Println("output")
+		os.Exit(3)
+	case "forever":
+		for i := 0; ; i++ {
+			fmt.Println(i)
+		}
+	case "frames":
+		_, _ = itermore.WriteFrames(os.Stdout, itermore.Items([]byte("a\nb"), []byte("c")), itermore.Uint32Codec(0))
+	}
+
+	os.Exit(0)
+}
+
+func collectCommand(seq iter.Seq2[[]byte, error]) ([]string, error) {
+	var lines []string
+	for line, err := range seq {
+		if err != nil {
+			return lines, err
+		}
+		lines = append(lines, string(line))
+	}
+
+	return lines, nil
+}
+
+func TestCommand(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("lines", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := collectCommand(itermore.Command(ctx, helperCommand(t, "lines")))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := []string{"first", "second", "third"}; !slices.Equal(got, want) {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("stdin", func(t *testing.T) {
+		t.Parallel()
+
+		stdin := itermore.Items([]byte("foo\nb"), []byte("ar\n"), []byte("baz"))
+		got, err := collectCommand(itermore.Command(ctx, helperCommand(t, "upper"), itermore.WithStdin(stdin)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := []string{"FOO", "BAR", "BAZ"}; !slices.Equal(got, want) {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("frames", func(t *testing.T) {
+		t.Parallel()
+
+		seq := itermore.Command(ctx, helperCommand(t, "frames"), itermore.WithFrames(itermore.Uint32Codec(0)))
+		got, err := collectCommand(seq)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := []string{"a\nb", "c"}; !slices.Equal(got, want) {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("exit-error", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := collectCommand(itermore.Command(ctx, helperCommand(t, "fail")))
+
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+			t.Errorf("got %v, want exit status 3", err)
+		}
+
+		if want := []string{"output"}; !slices.Equal(got, want) {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("start-error", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := exec.Command("itermore-command-which-does-not-exist")
+		_, err := collectCommand(itermore.Command(ctx, cmd))
+		if !errors.Is(err, exec.ErrNotFound) {
+			t.Errorf("got %v, want %v", err, exec.ErrNotFound)
+		}
+
+		cmd = helperCommand(t, "lines")
+		cmd.Stdout = io.Discard
+		if _, err := collectCommand(itermore.Command(ctx, cmd)); err == nil {
+			t.Error("expected error for command with stdout")
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		t.Parallel()
+
+		cmd := helperCommand(t, "forever")
+		for line := range itermore.Command(ctx, cmd) {
+			if string(line) == "10" {
+				break
+			}
+		}
+
+		if cmd.ProcessState == nil || cmd.ProcessState.Success() {
+			t.Errorf("expected killed process, got state %v", cmd.ProcessState)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		_, err := collectCommand(func(yield func([]byte, error) bool) {
+			for line, err := range itermore.Command(ctx, helperCommand(t, "forever")) {
+				if string(line) == "10" {
+					cancel()
+				}
+
+				if !yield(line, err) {
+					return
+				}
+			}
+		})
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got %v, want %v", err, context.Canceled)
+		}
+	})
+}
@@ -0,0 +1,343 @@
+package itermore
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"strconv"
+)
+
+// CSVOption configures csv.Reader used by CSV sequences.
+// It can be used to set separator, comment character, quotes handling, etc:
+//
+//	semicolon := func(r *csv.Reader) { r.Comma = ';' }
+type CSVOption func(*csv.Reader)
+
+func newCSVReader(re io.Reader, opts []CSVOption) *csv.Reader {
+	reader := csv.NewReader(re)
+	for _, opt := range opts {
+		opt(reader)
+	}
+	reader.ReuseRecord = true
+
+	return reader
+}
+
+// CSVRecords creates a sequence that yields records from the given CSV stream.
+// Records share the underlying memory and are valid until the next iteration.
+//
+// The sequence is error-aware: if reading or parsing fails, it yields the error with a nil record as its last pair.
+// Parsing errors are reported as *csv.ParseError, which contains the line number.
+func CSVRecords(re io.Reader, opts ...CSVOption) iter.Seq2[[]string, error] {
+	return func(yield func([]string, error) bool) {
+		reader := newCSVReader(re, opts)
+
+		for {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if !yield(record, nil) {
+				return
+			}
+		}
+	}
+}
+
+// CSVMaps creates a sequence that yields records from the given CSV stream as maps keyed by the header.
+// The first record is used as the header.
+// Maps are reused between iterations and are valid until the next iteration.
+// See CSVRecords for error handling.
+func CSVMaps(re io.Reader, opts ...CSVOption) iter.Seq2[map[string]string, error] {
+	return func(yield func(map[string]string, error) bool) {
+		var header []string
+		var row map[string]string
+
+		for record, err := range CSVRecords(re, opts...) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if header == nil {
+				header = append([]string{}, record...)
+				row = make(map[string]string, len(header))
+				continue
+			}
+
+			clear(row)
+			for i, value := range record {
+				if i < len(header) {
+					row[header[i]] = value
+				}
+			}
+
+			if !yield(row, nil) {
+				return
+			}
+		}
+	}
+}
+
+// CSVDecode creates a sequence that yields structs decoded from the given CSV stream.
+// The first record is used as the header. Columns are mapped to exported struct fields
+// by `csv:"name"` tags or by field names. Fields tagged with `csv:"-"` are ignored,
+// as well as columns without matching fields.
+// Fields of embedded structs are promoted, nil embedded pointers are allocated when decoding.
+//
+// Supported field types are strings, booleans, numbers and types implementing encoding.TextUnmarshaler.
+// Empty values are decoded as zero values.
+//
+// See CSVRecords for error handling. Decoding errors are reported as *LineError.
+// It yields an error if T is not a struct.
+func CSVDecode[T any](re io.Reader, opts ...CSVOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var empty T
+
+		fields, err := csvFields(reflect.TypeFor[T]())
+		if err != nil {
+			yield(empty, err)
+			return
+		}
+
+		reader := newCSVReader(re, opts)
+
+		// columns maps record columns to struct fields
+		var columns []*csvField
+
+		for {
+			record, err := reader.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(empty, err)
+				return
+			}
+
+			if columns == nil {
+				columns = make([]*csvField, len(record))
+				for i, name := range record {
+					for j := range fields {
+						if fields[j].name == name {
+							columns[i] = &fields[j]
+						}
+					}
+				}
+				continue
+			}
+
+			var value T
+			target := reflect.ValueOf(&value).Elem()
+
+			for i, column := range columns {
+				if column == nil || i >= len(record) {
+					continue
+				}
+
+				if err := decodeCSVValue(fieldByIndexAlloc(target, column.index), record[i]); err != nil {
+					line, _ := reader.FieldPos(i)
+					err = fmt.Errorf("column %q: %w", column.name, err)
+
+					yield(empty, &LineError{Line: line, Err: err})
+					return
+				}
+			}
+
+			if !yield(value, nil) {
+				return
+			}
+		}
+	}
+}
+
+// WriteCSV writes structs from the given sequence to the writer as CSV with a header.
+// Field mapping and supported types are the same as for CSVDecode,
+// types implementing encoding.TextMarshaler are also supported.
+// Fields promoted through nil embedded pointers are written as empty values.
+// It returns an error if T is not a struct.
+func WriteCSV[T any](wr io.Writer, seq iter.Seq[T]) error {
+	fields, err := csvFields(reflect.TypeFor[T]())
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(wr)
+
+	record := make([]string, len(fields))
+	for i, field := range fields {
+		record[i] = field.name
+	}
+
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+
+	for value := range seq {
+		source := reflect.ValueOf(&value).Elem()
+
+		for i, field := range fields {
+			dst, err := source.FieldByIndexErr(field.index)
+			if err != nil {
+				// the field is promoted through a nil embedded pointer
+				record[i] = ""
+				continue
+			}
+
+			str, err := encodeCSVValue(dst)
+			if err != nil {
+				return fmt.Errorf("column %q: %w", field.name, err)
+			}
+			record[i] = str
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+type csvField struct {
+	name  string
+	index []int
+}
+
+func csvFields(typ reflect.Type) ([]csvField, error) {
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("itermore: CSV mapping requires a struct type, got %v", typ)
+	}
+
+	var fields []csvField
+	for _, field := range reflect.VisibleFields(typ) {
+		if !field.IsExported() || field.Anonymous || !isFieldReachable(typ, field.Index) {
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("csv"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+
+		fields = append(fields, csvField{name: name, index: field.Index})
+	}
+
+	return fields, nil
+}
+
+// isFieldReachable reports whether the field with the given index can be set via reflection.
+// Fields promoted through unexported embedded pointers can't be allocated, so they are not reachable.
+func isFieldReachable(typ reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		field := typ.Field(i)
+		typ = field.Type
+
+		if typ.Kind() == reflect.Pointer {
+			if !field.IsExported() {
+				return false
+			}
+			typ = typ.Elem()
+		}
+	}
+
+	return true
+}
+
+// fieldByIndexAlloc is like reflect.Value.FieldByIndex,
+// but allocates nil embedded pointers along the path instead of panicking.
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v
+}
+
+func decodeCSVValue(dst reflect.Value, value string) error {
+	if u, ok := dst.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(value))
+	}
+
+	if dst.Kind() != reflect.String && value == "" {
+		dst.SetZero()
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(value, 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %v", dst.Type())
+	}
+
+	return nil
+}
+
+func encodeCSVValue(src reflect.Value) (string, error) {
+	if m, ok := src.Addr().Interface().(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		return string(text), err
+	}
+
+	switch src.Kind() {
+	case reflect.String:
+		return src.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(src.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(src.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(src.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(src.Float(), 'g', -1, src.Type().Bits()), nil
+	default:
+		return "", fmt.Errorf("unsupported field type %v", src.Type())
+	}
+}
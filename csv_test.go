@@ -0,0 +1,272 @@
+package itermore_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ninedraft/itermore"
+)
+
+type csvRow struct {
+	Name    string    `csv:"name"`
+	Age     int       `csv:"age"`
+	Score   float64   `csv:"score"`
+	Active  bool      `csv:"active"`
+	Joined  time.Time `csv:"joined"`
+	Comment string
+	Ignored string `csv:"-"`
+	hidden  string
+}
+
+type CSVBase struct {
+	ID int `csv:"id"`
+}
+
+type csvBase struct {
+	Secret string `csv:"secret"`
+}
+
+type csvEmbedRow struct {
+	*CSVBase
+	*csvBase
+	Name string `csv:"name"`
+}
+
+func ExampleCSVDecode() {
+	input := strings.NewReader("name,age\nalice,31\nbob,27\n")
+
+	type person struct {
+		Name string `csv:"name"`
+		Age  int    `csv:"age"`
+	}
+
+	for p, err := range itermore.CSVDecode[person](input) {
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(p.Name, p.Age)
+	}
+	// Output: alice 31
+	// bob 27
+}
+
+func TestCSVRecords(t *testing.T) {
+	t.Parallel()
+
+	assertBreak2(t, itermore.CSVRecords(strings.NewReader("a,b\nc,d")))
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		input := strings.NewReader("a;b\n# comment\n\"c;\";d\n")
+		semicolon := func(r *csv.Reader) {
+			r.Comma = ';'
+			r.Comment = '#'
+		}
+
+		got := [][]string{}
+		for record, err := range itermore.CSVRecords(input, semicolon) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, slices.Clone(record))
+		}
+
+		want := [][]string{{"a", "b"}, {"c;", "d"}}
+		if !slices.EqualFunc(got, want, slices.Equal) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+	})
+
+	t.Run("parse-error", func(t *testing.T) {
+		t.Parallel()
+
+		input := strings.NewReader("a,b\nc,d\ne\n")
+		_, err := collectSeqErr(itermore.CSVRecords(input))
+
+		var parseErr *csv.ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("got %v, want *csv.ParseError", err)
+		}
+
+		if parseErr.Line != 3 {
+			t.Errorf("got line %d, want %d", parseErr.Line, 3)
+		}
+	})
+}
+
+func TestCSVMaps(t *testing.T) {
+	t.Parallel()
+
+	assertBreak2(t, itermore.CSVMaps(strings.NewReader("a,b\nc,d")))
+
+	input := strings.NewReader("name,age\nalice,31\nbob,27\n")
+
+	got := []map[string]string{}
+	for row, err := range itermore.CSVMaps(input) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, maps.Clone(row))
+	}
+
+	want := []map[string]string{
+		{"name": "alice", "age": "31"},
+		{"name": "bob", "age": "27"},
+	}
+
+	if !slices.EqualFunc(got, want, maps.Equal) {
+		t.Errorf("got:  %v", got)
+		t.Errorf("want: %v", want)
+	}
+}
+
+func TestCSVDecode(t *testing.T) {
+	t.Parallel()
+
+	joined := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	assertBreak2(t, itermore.CSVDecode[csvRow](strings.NewReader("name\na\nb")))
+
+	t.Run("round-trip", func(t *testing.T) {
+		t.Parallel()
+
+		want := []csvRow{
+			{Name: "alice", Age: 31, Score: 4.5, Active: true, Joined: joined, Comment: "a, \"quoted\" text"},
+			{Name: "bob", Age: 27, Score: -1, Joined: joined.Add(time.Hour)},
+		}
+
+		buf := &strings.Builder{}
+		if err := itermore.WriteCSV(buf, itermore.Slice(want)); err != nil {
+			t.Fatal(err)
+		}
+
+		header, _, _ := strings.Cut(buf.String(), "\n")
+		if wantHeader := "name,age,score,active,joined,Comment"; header != wantHeader {
+			t.Errorf("got header %q, want %q", header, wantHeader)
+		}
+
+		got, err := collectSeqErr(itermore.CSVDecode[csvRow](strings.NewReader(buf.String())))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.EqualFunc(got, want, func(a, b csvRow) bool {
+			return a.Name == b.Name && a.Age == b.Age && a.Score == b.Score &&
+				a.Active == b.Active && a.Joined.Equal(b.Joined) && a.Comment == b.Comment
+		}) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("columns", func(t *testing.T) {
+		t.Parallel()
+
+		input := strings.NewReader("extra,age,name,Ignored\nx,,carol,y\n")
+		got, err := collectSeqErr(itermore.CSVDecode[csvRow](input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []csvRow{{Name: "carol"}}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("decode-error", func(t *testing.T) {
+		t.Parallel()
+
+		input := strings.NewReader("name,age\nalice,31\nbob,old\n")
+		got, err := collectSeqErr(itermore.CSVDecode[csvRow](input))
+
+		var lineErr *itermore.LineError
+		if !errors.As(err, &lineErr) {
+			t.Fatalf("got %v, want *itermore.LineError", err)
+		}
+
+		if lineErr.Line != 3 {
+			t.Errorf("got line %d, want %d", lineErr.Line, 3)
+		}
+
+		if !errors.Is(err, strconv.ErrSyntax) {
+			t.Errorf("got %v, want %v", err, strconv.ErrSyntax)
+		}
+
+		if len(got) != 1 {
+			t.Errorf("got %v, want a single row", got)
+		}
+	})
+
+	t.Run("embedded-pointer", func(t *testing.T) {
+		t.Parallel()
+
+		input := strings.NewReader("id,secret,name\n1,x,a\n,,b\n")
+		got, err := collectSeqErr(itermore.CSVDecode[csvEmbedRow](input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 2 {
+			t.Fatalf("got %d rows, want 2", len(got))
+		}
+
+		for i, row := range got {
+			if row.CSVBase == nil {
+				t.Fatalf("row %d: embedded pointer must be allocated", i)
+			}
+
+			if row.csvBase != nil {
+				t.Errorf("row %d: unexported embedded pointer must not be allocated", i)
+			}
+		}
+
+		if got[0].ID != 1 || got[0].Name != "a" || got[1].ID != 0 || got[1].Name != "b" {
+			t.Errorf("got %+v, %+v", *got[0].CSVBase, *got[1].CSVBase)
+		}
+
+		buf := &strings.Builder{}
+		rows := itermore.Items(csvEmbedRow{CSVBase: &CSVBase{ID: 2}, Name: "c"}, csvEmbedRow{Name: "d"})
+		if err := itermore.WriteCSV(buf, rows); err != nil {
+			t.Fatal(err)
+		}
+
+		if want := "id,name\n2,c\n,d\n"; buf.String() != want {
+			t.Errorf("got %q, want %q", buf.String(), want)
+		}
+	})
+
+	t.Run("not-struct", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := collectSeqErr(itermore.CSVDecode[int](strings.NewReader("a\n1\n")))
+		if err == nil {
+			t.Fatal("expected error for non-struct type")
+		}
+
+		if err := itermore.WriteCSV(&strings.Builder{}, itermore.Items(1)); err == nil {
+			t.Fatal("expected error for non-struct type")
+		}
+	})
+}
+
+func TestWriteCSV(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	err := itermore.WriteCSV(&errWriter{err: errTest}, itermore.Items(csvRow{Name: "a"}))
+	if !errors.Is(err, errTest) {
+		t.Fatalf("got %v, want %v", err, errTest)
+	}
+}
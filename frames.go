@@ -0,0 +1,213 @@
+package itermore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+)
+
+// ErrFrameTooLarge is reported when a frame exceeds the maximum size of the codec.
+var ErrFrameTooLarge = errors.New("frame is too large")
+
+// DefaultMaxFrameSize is the maximum frame size used by codecs created with non-positive size.
+const DefaultMaxFrameSize = bufio.MaxScanTokenSize
+
+// FrameCodec describes how frames are delimited in a byte stream.
+// Use NewlineCodec, UvarintCodec or Uint32Codec to create a codec.
+type FrameCodec struct {
+	kind    frameKind
+	maxSize int
+}
+
+type frameKind int
+
+const (
+	newlineFrames frameKind = iota
+	uvarintFrames
+	uint32Frames
+)
+
+// NewlineCodec creates a codec for newline-delimited frames.
+// Frames must not contain newlines, line endings are not included into frames.
+// If maxSize is not positive, DefaultMaxFrameSize is used.
+func NewlineCodec(maxSize int) FrameCodec {
+	return newFrameCodec(newlineFrames, maxSize)
+}
+
+// UvarintCodec creates a codec for frames prefixed with their length, encoded as unsigned varint.
+// If maxSize is not positive, DefaultMaxFrameSize is used.
+func UvarintCodec(maxSize int) FrameCodec {
+	return newFrameCodec(uvarintFrames, maxSize)
+}
+
+// Uint32Codec creates a codec for frames prefixed with their length, encoded as 4-byte big-endian integer.
+// If maxSize is not positive, DefaultMaxFrameSize is used.
+func Uint32Codec(maxSize int) FrameCodec {
+	return newFrameCodec(uint32Frames, maxSize)
+}
+
+func newFrameCodec(kind frameKind, maxSize int) FrameCodec {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxFrameSize
+	}
+
+	return FrameCodec{kind: kind, maxSize: maxSize}
+}
+
+// MaxSize returns the maximum frame size.
+func (codec FrameCodec) MaxSize() int {
+	return codec.maxSize
+}
+
+// Frames creates a sequence that yields frames from the given reader.
+// Yielded frames share the underlying buffer and are valid until the next iteration.
+//
+// The sequence is error-aware: if reading fails, it yields the error with a nil frame as its last pair.
+// Frames larger than the codec maximum size are reported as ErrFrameTooLarge,
+// truncated frames are reported as io.ErrUnexpectedEOF.
+func Frames(re io.Reader, codec FrameCodec) iter.Seq2[[]byte, error] {
+	if codec.kind == newlineFrames {
+		return newlineFramesSeq(re, codec)
+	}
+
+	return func(yield func([]byte, error) bool) {
+		br := bufio.NewReader(re)
+
+		var buf []byte
+		for {
+			size, err := codec.readHeader(br)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			if size > uint64(codec.maxSize) {
+				yield(nil, fmt.Errorf("%w: %d bytes, max %d", ErrFrameTooLarge, size, codec.maxSize))
+				return
+			}
+
+			buf = grow(buf, int(size))
+			if _, err := io.ReadFull(br, buf); err != nil {
+				yield(nil, fmt.Errorf("reading frame: %w", noEOF(err)))
+				return
+			}
+
+			if !yield(buf, nil) {
+				return
+			}
+		}
+	}
+}
+
+func newlineFramesSeq(re io.Reader, codec FrameCodec) iter.Seq2[[]byte, error] {
+	// frame is followed by a line ending, which must fit into the scanner buffer
+	frames := Scan(re, scanNewlineFrames, WithMaxTokenSize(codec.maxSize+1))
+
+	return func(yield func([]byte, error) bool) {
+		for frame, err := range frames {
+			if errors.Is(err, bufio.ErrTooLong) {
+				err = fmt.Errorf("%w: max %d bytes", ErrFrameTooLarge, codec.maxSize)
+			}
+
+			if !yield(frame, err) {
+				return
+			}
+		}
+	}
+}
+
+// readHeader reads the length prefix of the frame.
+// It returns io.EOF only if there are no more frames.
+func (codec FrameCodec) readHeader(br *bufio.Reader) (uint64, error) {
+	switch codec.kind {
+	case uvarintFrames:
+		size, err := binary.ReadUvarint(br)
+		if err != nil && !errors.Is(err, io.EOF) {
+			err = fmt.Errorf("reading frame length: %w", err)
+		}
+		return size, err
+	case uint32Frames:
+		var header [4]byte
+		_, err := io.ReadFull(br, header[:])
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			err = fmt.Errorf("reading frame length: %w", err)
+		}
+		return uint64(binary.BigEndian.Uint32(header[:])), err
+	default:
+		panic("itermore.FrameCodec: unexpected codec kind")
+	}
+}
+
+// appendHeader appends the frame header to dst.
+func (codec FrameCodec) appendHeader(dst []byte, size int) []byte {
+	switch codec.kind {
+	case uvarintFrames:
+		return binary.AppendUvarint(dst, uint64(size))
+	case uint32Frames:
+		return binary.BigEndian.AppendUint32(dst, uint32(size))
+	default:
+		return dst
+	}
+}
+
+// WriteFrames writes frames from the given sequence to the writer, encoding them with the codec.
+// Each frame is written with a single Write call, together with its header or line ending.
+// It returns number of written bytes and the first error encountered.
+// Frames larger than the codec maximum size are reported as ErrFrameTooLarge.
+// Newline-delimited frames must not contain newlines.
+func WriteFrames[P ~[]byte](wr io.Writer, seq iter.Seq[P], codec FrameCodec) (int64, error) {
+	written := int64(0)
+
+	// buf holds the encoded frame and is reused between frames
+	var buf []byte
+	for frame := range seq {
+		if len(frame) > codec.maxSize || (codec.kind == uint32Frames && uint64(len(frame)) > math.MaxUint32) {
+			return written, fmt.Errorf("%w: %d bytes, max %d", ErrFrameTooLarge, len(frame), codec.maxSize)
+		}
+
+		buf = codec.appendHeader(buf[:0], len(frame))
+		buf = append(buf, frame...)
+
+		if codec.kind == newlineFrames {
+			if bytes.IndexByte(frame, '\n') >= 0 {
+				return written, errors.New("newline-delimited frame contains a newline")
+			}
+			buf = append(buf, '\n')
+		}
+
+		n, err := wr.Write(buf)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
+
+// scanNewlineFrames is like bufio.ScanLines, but strips only the '\n' character.
+func scanNewlineFrames(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	advance, token, err = scanLinesWithEndings(data, atEOF)
+	if len(token) > 0 && token[len(token)-1] == '\n' {
+		token = token[:len(token)-1]
+	}
+
+	return advance, token, err
+}
+
+// grow returns a slice of the given length, reusing buf if possible.
+func grow(buf []byte, size int) []byte {
+	if cap(buf) < size {
+		return make([]byte, size)
+	}
+
+	return buf[:size]
+}
@@ -0,0 +1,226 @@
+package itermore_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ninedraft/itermore"
+)
+
+func ExampleFrames() {
+	buf := &bytes.Buffer{}
+	frames := itermore.Items([]byte("hello"), []byte("world"))
+
+	codec := itermore.UvarintCodec(1024)
+	_, _ = itermore.WriteFrames(buf, frames, codec)
+
+	for frame, err := range itermore.Frames(buf, codec) {
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(string(frame))
+	}
+	// Output: hello
+	// world
+}
+
+func TestFrames(t *testing.T) {
+	t.Parallel()
+
+	codecs := map[string]itermore.FrameCodec{
+		"newline": itermore.NewlineCodec(16),
+		"uvarint": itermore.UvarintCodec(16),
+		"uint32":  itermore.Uint32Codec(16),
+	}
+
+	want := []string{"alpha", "", "beta\r", strings.Repeat("g", 16)}
+
+	for name, codec := range codecs {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			{
+				buf := &bytes.Buffer{}
+				_, _ = itermore.WriteFrames(buf, itermore.Items([]byte("a")), codec)
+				assertBreak2(t, itermore.Frames(buf, codec))
+			}
+
+			t.Run("pipe", func(t *testing.T) {
+				t.Parallel()
+
+				client, server := net.Pipe()
+				t.Cleanup(func() { _ = server.Close() })
+
+				written := make(chan error, 1)
+				go func() {
+					defer client.Close()
+
+					frames := itermore.Slice(want)
+					_, err := itermore.WriteFrames(client, toBytes(frames), codec)
+					written <- err
+				}()
+
+				got := []string{}
+				for frame, err := range itermore.Frames(server, codec) {
+					if err != nil {
+						t.Fatal(err)
+					}
+					got = append(got, string(frame))
+				}
+
+				if err := <-written; err != nil {
+					t.Fatal(err)
+				}
+
+				if !slices.Equal(got, want) {
+					t.Errorf("got:  %q", got)
+					t.Errorf("want: %q", want)
+				}
+			})
+
+			t.Run("write-calls", func(t *testing.T) {
+				t.Parallel()
+
+				wr := &writeCounter{}
+				n, err := itermore.WriteFrames(wr, toBytes(itermore.Slice(want)), codec)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if wr.calls != len(want) {
+					t.Errorf("got %d Write calls, want one per frame: %d", wr.calls, len(want))
+				}
+
+				if n != int64(wr.Len()) {
+					t.Errorf("got %d written bytes, want %d", n, wr.Len())
+				}
+			})
+
+			t.Run("write-too-large", func(t *testing.T) {
+				t.Parallel()
+
+				frames := itermore.Items([]byte("ok"), bytes.Repeat([]byte("x"), 17))
+
+				buf := &bytes.Buffer{}
+				_, err := itermore.WriteFrames(buf, frames, codec)
+				if !errors.Is(err, itermore.ErrFrameTooLarge) {
+					t.Fatalf("got %v, want %v", err, itermore.ErrFrameTooLarge)
+				}
+
+				got, err := collectSeqErr(toStrings(itermore.Frames(buf, codec)))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if want := []string{"ok"}; !slices.Equal(got, want) {
+					t.Errorf("got:  %q", got)
+					t.Errorf("want: %q", want)
+				}
+			})
+
+			t.Run("read-too-large", func(t *testing.T) {
+				t.Parallel()
+
+				large := itermore.Items([]byte("ok"), bytes.Repeat([]byte("x"), 32))
+
+				buf := &bytes.Buffer{}
+				if _, err := itermore.WriteFrames(buf, large, itermore.UvarintCodec(32)); err != nil {
+					t.Fatal(err)
+				}
+
+				input := buf.Bytes()
+				if name == "newline" {
+					input = []byte("ok\n" + strings.Repeat("x", 32) + "\n")
+				}
+				if name == "uint32" {
+					input = []byte("\x00\x00\x00\x02ok\x00\x00\x00\x20" + strings.Repeat("x", 32))
+				}
+
+				got, err := collectSeqErr(toStrings(itermore.Frames(bytes.NewReader(input), codec)))
+				if !errors.Is(err, itermore.ErrFrameTooLarge) {
+					t.Fatalf("got %v, want %v", err, itermore.ErrFrameTooLarge)
+				}
+
+				if want := []string{"ok"}; !slices.Equal(got, want) {
+					t.Errorf("got:  %q", got)
+					t.Errorf("want: %q", want)
+				}
+			})
+		})
+	}
+}
+
+func TestFramesTruncated(t *testing.T) {
+	t.Parallel()
+
+	for name, input := range map[string]string{
+		"uvarint-header":  "\x80",
+		"uvarint-payload": "\x05abc",
+		"uint32-header":   "\x00\x00",
+		"uint32-payload":  "\x00\x00\x00\x05abc",
+	} {
+		codec := itermore.UvarintCodec(0)
+		if strings.HasPrefix(name, "uint32") {
+			codec = itermore.Uint32Codec(0)
+		}
+
+		_, err := collectSeqErr(itermore.Frames(strings.NewReader(input), codec))
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("%s: got %v, want %v", name, err, io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+func TestWriteFramesNewline(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	frames := itermore.Items([]byte("a"), []byte("b\nc"))
+
+	n, err := itermore.WriteFrames(buf, frames, itermore.NewlineCodec(0))
+	if err == nil {
+		t.Fatal("frames with newlines must be rejected")
+	}
+
+	if n != 2 || buf.String() != "a\n" {
+		t.Errorf("got %d, %q; want 2, %q", n, buf.String(), "a\n")
+	}
+}
+
+// writeCounter counts Write calls.
+type writeCounter struct {
+	bytes.Buffer
+	calls int
+}
+
+func (wc *writeCounter) Write(p []byte) (int, error) {
+	wc.calls++
+	return wc.Buffer.Write(p)
+}
+
+func toBytes(seq iter.Seq[string]) iter.Seq[[]byte] {
+	return func(yield func([]byte) bool) {
+		for s := range seq {
+			if !yield([]byte(s)) {
+				return
+			}
+		}
+	}
+}
+
+func toStrings(seq iter.Seq2[[]byte, error]) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		for frame, err := range seq {
+			if !yield(string(frame), err) {
+				return
+			}
+		}
+	}
+}
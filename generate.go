@@ -0,0 +1,81 @@
+package itermore
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// ErrConsumerStopped is returned by the emit function of Generate, when the consumer has stopped the iteration.
+var ErrConsumerStopped = errors.New("consumer has stopped")
+
+// Generate creates a sequence, which runs the producer in a separate goroutine and yields values passed to emit.
+// It allows to turn push-style producers, like callbacks and event handlers, into sequences.
+//
+// The emit function blocks until the consumer receives the value, which provides backpressure.
+// It is safe for concurrent use, but must not be called after the producer returns.
+// If the consumer stops the iteration, the producer context is canceled and emit returns ErrConsumerStopped.
+// If ctx is canceled, emit returns ctx error.
+//
+// The sequence is error-aware: if the producer returns an error, it is yielded with a zero value as the last pair.
+// If the producer panics, the panic is propagated to the consumer as *PanicError.
+// Stopping the sequence waits for the producer to return.
+func Generate[E any](ctx context.Context, produce func(ctx context.Context, emit func(E) error) error) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		ctx, cancel := context.WithCancelCause(ctx)
+
+		values := make(chan E)
+		done := make(chan struct{})
+
+		var produceErr error
+		var panicErr *PanicError
+
+		emit := func(value E) error {
+			if ctx.Err() != nil {
+				return context.Cause(ctx)
+			}
+
+			select {
+			case values <- value:
+				return nil
+			case <-ctx.Done():
+				return context.Cause(ctx)
+			}
+		}
+
+		go func() {
+			defer close(done)
+			defer func() {
+				if p := recover(); p != nil {
+					panicErr = newPanicError(p)
+				}
+			}()
+
+			produceErr = produce(ctx, emit)
+		}()
+
+		defer func() {
+			cancel(ErrConsumerStopped)
+			<-done
+
+			if panicErr != nil {
+				panic(panicErr)
+			}
+		}()
+
+		for {
+			select {
+			case value := <-values:
+				if !yield(value, nil) {
+					return
+				}
+			case <-done:
+				if produceErr != nil {
+					var empty E
+					yield(empty, produceErr)
+				}
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package itermore_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ninedraft/itermore"
+)
+
+func ExampleGenerate() {
+	// subscribe simulates a callback-based API
+	subscribe := func(handler func(event string)) {
+		for _, event := range []string{"connected", "message", "disconnected"} {
+			handler(event)
+		}
+	}
+
+	events := itermore.Generate(context.Background(), func(_ context.Context, emit func(string) error) error {
+		var err error
+		subscribe(func(event string) {
+			if err == nil {
+				err = emit(event)
+			}
+		})
+		return err
+	})
+
+	for event, err := range events {
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(event)
+	}
+	// Output: connected
+	// message
+	// disconnected
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	counter := func(n int) func(context.Context, func(int) error) error {
+		return func(_ context.Context, emit func(int) error) error {
+			for i := range n {
+				if err := emit(i); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	assertBreak2(t, itermore.Generate(ctx, counter(10)))
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := collectSeqErr(itermore.Generate(ctx, counter(5)))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := []int{0, 1, 2, 3, 4}; !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("concurrent-emit", func(t *testing.T) {
+		t.Parallel()
+
+		seq := itermore.Generate(ctx, func(_ context.Context, emit func(int) error) error {
+			wg := &sync.WaitGroup{}
+			for i := range 10 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_ = emit(i)
+				}()
+			}
+			wg.Wait()
+			return nil
+		})
+
+		got, err := collectSeqErr(seq)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		slices.Sort(got)
+		if want := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}; !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("producer-error", func(t *testing.T) {
+		t.Parallel()
+
+		errTest := errors.New("test error")
+		seq := itermore.Generate(ctx, func(_ context.Context, emit func(int) error) error {
+			_ = emit(1)
+			return errTest
+		})
+
+		got, err := collectSeqErr(seq)
+		if !errors.Is(err, errTest) {
+			t.Errorf("got %v, want %v", err, errTest)
+		}
+
+		if !slices.Equal(got, []int{1}) {
+			t.Errorf("got %v, want [1]", got)
+		}
+	})
+
+	t.Run("consumer-stop", func(t *testing.T) {
+		t.Parallel()
+
+		var emitErr, ctxErr error
+		seq := itermore.Generate(ctx, func(ctx context.Context, emit func(int) error) error {
+			for i := 0; ; i++ {
+				if err := emit(i); err != nil {
+					emitErr, ctxErr = err, ctx.Err()
+					return err
+				}
+			}
+		})
+
+		for value := range seq {
+			if value == 3 {
+				break
+			}
+		}
+
+		// the producer must have returned before the iteration stops
+		if !errors.Is(emitErr, itermore.ErrConsumerStopped) {
+			t.Errorf("got emit error %v, want %v", emitErr, itermore.ErrConsumerStopped)
+		}
+
+		if !errors.Is(ctxErr, context.Canceled) {
+			t.Errorf("got ctx error %v, want %v", ctxErr, context.Canceled)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		seq := itermore.Generate(ctx, func(ctx context.Context, emit func(int) error) error {
+			if err := emit(1); err != nil {
+				return err
+			}
+
+			<-ctx.Done()
+			return emit(2)
+		})
+
+		got, err := collectSeqErr(seq)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got %v, want %v", err, context.DeadlineExceeded)
+		}
+
+		if !slices.Equal(got, []int{1}) {
+			t.Errorf("got %v, want [1]", got)
+		}
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		t.Parallel()
+
+		errTest := errors.New("test error")
+		seq := itermore.Generate(ctx, func(_ context.Context, emit func(int) error) error {
+			_ = emit(1)
+			panic(errTest)
+		})
+
+		defer func() {
+			p := recover()
+
+			pe, ok := p.(*itermore.PanicError)
+			if !ok {
+				t.Fatalf("got panic %v, want *itermore.PanicError", p)
+			}
+
+			if !errors.Is(pe, errTest) {
+				t.Errorf("got %v, want %v", pe, errTest)
+			}
+		}()
+
+		for range seq {
+		}
+
+		t.Fatal("panic is not propagated")
+	})
+}
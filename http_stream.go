@@ -0,0 +1,275 @@
+package itermore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// StreamErrorTrailer is the HTTP trailer, which reports errors of streamed responses.
+const StreamErrorTrailer = "X-Stream-Error"
+
+// ErrStreamStarted wraps errors of StreamJSONArray and StreamNDJSON, which happen after the response is started.
+// The status and headers are already sent at this point, so the caller must not write to the response anymore,
+// e.g. with http.Error.
+var ErrStreamStarted = errors.New("response stream is already started")
+
+// ErrRemoteStream is reported by response decoders, when the server reports a stream error in StreamErrorTrailer.
+var ErrRemoteStream = errors.New("remote stream error")
+
+// StreamOption configures StreamJSONArray and StreamNDJSON.
+type StreamOption func(*streamConfig)
+
+type streamConfig struct {
+	flushInterval time.Duration
+}
+
+// WithFlushInterval sets the minimum interval between response flushes, 100ms by default.
+// Zero interval makes the response flushed after each element.
+// It will panic if interval is negative.
+func WithFlushInterval(interval time.Duration) StreamOption {
+	if interval < 0 {
+		panic("itermore.WithFlushInterval: interval must not be negative")
+	}
+
+	return func(cfg *streamConfig) {
+		cfg.flushInterval = interval
+	}
+}
+
+// StreamJSONArray writes elements from the given sequence to the response as a JSON array.
+// Elements are encoded and written as they are produced, the response is flushed periodically
+// and after the last element. Content-Type is set to application/json, if it is not set yet.
+//
+// If the sequence fails before the first element, nothing is written and the error is returned,
+// so the caller can respond with an error status.
+// If it fails later, the array is left unterminated, the error message is sent in StreamErrorTrailer
+// and the error is returned wrapped in ErrStreamStarted. It returns an error if encoding or writing fails as well,
+// errors after the response is started are wrapped in ErrStreamStarted too:
+//
+//	err := StreamJSONArray(w, seq)
+//	if err != nil && !errors.Is(err, ErrStreamStarted) {
+//		http.Error(w, err.Error(), http.StatusInternalServerError)
+//	}
+//
+// Plain sequences can be adapted with NoErrors.
+func StreamJSONArray[E any](w http.ResponseWriter, seq iter.Seq2[E, error], opts ...StreamOption) error {
+	return streamJSON(w, seq, opts, jsonFraming{
+		contentType: "application/json",
+		open:        "[",
+		sep:         ",",
+		close:       "]\n",
+		empty:       "[]\n",
+	})
+}
+
+// StreamNDJSON writes elements from the given sequence to the response as NDJSON (JSON Lines) stream.
+// Content-Type is set to application/x-ndjson, if it is not set yet.
+// See StreamJSONArray for details on flushing and error handling.
+func StreamNDJSON[E any](w http.ResponseWriter, seq iter.Seq2[E, error], opts ...StreamOption) error {
+	return streamJSON(w, seq, opts, jsonFraming{
+		contentType: "application/x-ndjson",
+		after:       "\n",
+	})
+}
+
+// jsonFraming describes how elements are framed in a stream.
+type jsonFraming struct {
+	contentType string
+	// open is written before the first element.
+	open string
+	// sep is written before each element except the first one.
+	sep string
+	// after is written after each element.
+	after string
+	// close is written after the last element.
+	close string
+	// empty is written, if there are no elements.
+	empty string
+}
+
+func streamJSON[E any](w http.ResponseWriter, seq iter.Seq2[E, error], opts []StreamOption, framing jsonFraming) (err error) {
+	cfg := &streamConfig{
+		flushInterval: 100 * time.Millisecond,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	sw := &streamWriter{
+		w:        w,
+		rc:       http.NewResponseController(w),
+		interval: cfg.flushInterval,
+	}
+
+	started := false
+	defer func() {
+		if err != nil && started {
+			err = fmt.Errorf("%w: %w", ErrStreamStarted, err)
+		}
+	}()
+
+	start := func() {
+		started = true
+
+		header := w.Header()
+		if header.Get("Content-Type") == "" {
+			header.Set("Content-Type", framing.contentType)
+		}
+	}
+
+	var buf []byte
+	for value, err := range seq {
+		if err == nil {
+			buf, err = appendJSON(buf[:0], value)
+		}
+
+		if err != nil {
+			if started {
+				// trailers with the prefix don't need to be declared before writing the body
+				msg := strings.NewReplacer("\r", " ", "\n", " ").Replace(err.Error())
+				w.Header().Set(http.TrailerPrefix+StreamErrorTrailer, msg)
+				_ = sw.flush()
+			}
+
+			return err
+		}
+
+		prefix := framing.sep
+		if !started {
+			start()
+			prefix = framing.open
+		}
+
+		if err := sw.write(prefix, buf, framing.after); err != nil {
+			return err
+		}
+	}
+
+	suffix := framing.close
+	if !started {
+		start()
+		suffix = framing.empty
+	}
+
+	if err := sw.write(suffix, nil, ""); err != nil {
+		return err
+	}
+
+	return sw.flush()
+}
+
+func appendJSON(dst []byte, value any) ([]byte, error) {
+	data, err := json.Marshal(value)
+
+	return append(dst, data...), err
+}
+
+// streamWriter writes to the response and flushes it periodically.
+type streamWriter struct {
+	w         http.ResponseWriter
+	rc        *http.ResponseController
+	interval  time.Duration
+	lastFlush time.Time
+}
+
+func (sw *streamWriter) write(prefix string, data []byte, suffix string) error {
+	for _, chunk := range [][]byte{[]byte(prefix), data, []byte(suffix)} {
+		if len(chunk) == 0 {
+			continue
+		}
+
+		if _, err := sw.w.Write(chunk); err != nil {
+			return err
+		}
+	}
+
+	if now := time.Now(); now.Sub(sw.lastFlush) >= sw.interval {
+		sw.lastFlush = now
+		return sw.flush()
+	}
+
+	return nil
+}
+
+// flush flushes the response. Writers, which don't support flushing, are not considered broken:
+// the response is sent, when the handler returns.
+func (sw *streamWriter) flush() error {
+	err := sw.rc.Flush()
+	if errors.Is(err, http.ErrNotSupported) {
+		return nil
+	}
+
+	return err
+}
+
+// DecodeJSONArrayResponse creates a sequence that yields elements of the JSON array from the response body.
+// It is a client-side counterpart of StreamJSONArray. See DecodeJSONArray for details.
+//
+// The sequence is error-aware: if the response status is not 2xx, decoding fails or the server reports an error
+// in StreamErrorTrailer, it yields the error with a zero value as its last pair.
+// Server errors are reported as ErrRemoteStream.
+// The response body is closed when the iteration stops, so the sequence can be iterated only once.
+func DecodeJSONArrayResponse[T any](resp *http.Response) iter.Seq2[T, error] {
+	return decodeResponse(resp, DecodeJSONArray[T])
+}
+
+// DecodeNDJSONResponse creates a sequence that yields values from the NDJSON (JSON Lines) response body.
+// It is a client-side counterpart of StreamNDJSON. See DecodeJSONLines for details.
+// See DecodeJSONArrayResponse for error handling.
+func DecodeNDJSONResponse[T any](resp *http.Response, opts ...ScanOption) iter.Seq2[T, error] {
+	return decodeResponse(resp, func(re io.Reader) iter.Seq2[T, error] {
+		return DecodeJSONLines[T](re, opts...)
+	})
+}
+
+func decodeResponse[T any](resp *http.Response, decode func(io.Reader) iter.Seq2[T, error]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var empty T
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+			yield(empty, fmt.Errorf("unexpected response status %s: %q", resp.Status, body))
+			return
+		}
+
+		for value, err := range decode(resp.Body) {
+			if err != nil {
+				// trailers are available after the body is read till the end
+				if trailerErr := remoteStreamErr(resp); trailerErr != nil {
+					err = trailerErr
+				}
+
+				yield(empty, err)
+				return
+			}
+
+			if !yield(value, nil) {
+				return
+			}
+		}
+
+		// the body can contain trailing whitespace
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		if err := remoteStreamErr(resp); err != nil {
+			yield(empty, err)
+		}
+	}
+}
+
+func remoteStreamErr(resp *http.Response) error {
+	msg := resp.Trailer.Get(StreamErrorTrailer)
+	if msg == "" {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrRemoteStream, msg)
+}
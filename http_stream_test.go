@@ -0,0 +1,244 @@
+package itermore_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/ninedraft/itermore"
+)
+
+func ExampleStreamJSONArray() {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		events := itermore.NoErrors(itermore.Items(1, 2, 3))
+
+		err := itermore.StreamJSONArray(w, events)
+		if err != nil && !errors.Is(err, itermore.ErrStreamStarted) {
+			// the response is not started yet, so the error status can be sent
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		panic(err)
+	}
+
+	for value, err := range itermore.DecodeJSONArrayResponse[int](resp) {
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(value)
+	}
+	// Output: 1
+	// 2
+	// 3
+}
+
+// failingSeq yields n events and then fails with err, if it is not nil.
+func failingSeq(n int, err error) iter.Seq2[event, error] {
+	return func(yield func(event, error) bool) {
+		for i := range n {
+			if !yield(event{ID: i, Name: fmt.Sprint("event ", i)}, nil) {
+				return
+			}
+		}
+
+		if err != nil {
+			yield(event{}, err)
+		}
+	}
+}
+
+type streamFunc func(w http.ResponseWriter, seq iter.Seq2[event, error], opts ...itermore.StreamOption) error
+
+type decodeFunc func(resp *http.Response) iter.Seq2[event, error]
+
+func streamServer(t *testing.T, stream streamFunc, seq iter.Seq2[event, error]) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		err := stream(w, seq, itermore.WithFlushInterval(0))
+		if err != nil && !errors.Is(err, itermore.ErrStreamStarted) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// noFlushWriter hides the Flush method of the underlying writer.
+type noFlushWriter struct{ http.ResponseWriter }
+
+func TestStreamJSON(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	formats := []struct {
+		name   string
+		stream streamFunc
+		decode decodeFunc
+		want   string
+	}{
+		{
+			name:   "array",
+			stream: itermore.StreamJSONArray[event],
+			decode: itermore.DecodeJSONArrayResponse[event],
+			want:   `[{"id":0,"name":"event 0"},{"id":1,"name":"event 1"}]` + "\n",
+		},
+		{
+			name:   "ndjson",
+			stream: itermore.StreamNDJSON[event],
+			decode: func(resp *http.Response) iter.Seq2[event, error] {
+				return itermore.DecodeNDJSONResponse[event](resp)
+			},
+			want: `{"id":0,"name":"event 0"}` + "\n" + `{"id":1,"name":"event 1"}` + "\n",
+		},
+	}
+
+	for _, format := range formats {
+		t.Run(format.name, func(t *testing.T) {
+			t.Parallel()
+
+			t.Run("body", func(t *testing.T) {
+				t.Parallel()
+
+				rec := httptest.NewRecorder()
+				if err := format.stream(rec, failingSeq(2, nil)); err != nil {
+					t.Fatal(err)
+				}
+
+				if got := rec.Body.String(); got != format.want {
+					t.Errorf("got:  %q", got)
+					t.Errorf("want: %q", format.want)
+				}
+
+				if !rec.Flushed {
+					t.Error("response is not flushed")
+				}
+			})
+
+			t.Run("no-flusher", func(t *testing.T) {
+				t.Parallel()
+
+				rec := httptest.NewRecorder()
+				if err := format.stream(noFlushWriter{rec}, failingSeq(2, nil), itermore.WithFlushInterval(0)); err != nil {
+					t.Fatal(err)
+				}
+
+				if got := rec.Body.String(); got != format.want {
+					t.Errorf("got:  %q", got)
+					t.Errorf("want: %q", format.want)
+				}
+			})
+
+			t.Run("round-trip", func(t *testing.T) {
+				t.Parallel()
+
+				for _, n := range []int{0, 1, 100} {
+					server := streamServer(t, format.stream, failingSeq(n, nil))
+
+					resp, err := http.Get(server.URL)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					got, err := collectSeqErr(format.decode(resp))
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					want, _ := collectSeqErr(failingSeq(n, nil))
+					if !slices.Equal(got, want) {
+						t.Errorf("got %d events, want %d", len(got), len(want))
+					}
+				}
+			})
+
+			t.Run("trailer-error", func(t *testing.T) {
+				t.Parallel()
+
+				server := streamServer(t, format.stream, failingSeq(3, errTest))
+
+				resp, err := http.Get(server.URL)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got, err := collectSeqErr(format.decode(resp))
+				if !errors.Is(err, itermore.ErrRemoteStream) {
+					t.Errorf("got %v, want %v", err, itermore.ErrRemoteStream)
+				}
+
+				if len(got) != 3 {
+					t.Errorf("got %d events before error, want %d", len(got), 3)
+				}
+			})
+
+			t.Run("started-error", func(t *testing.T) {
+				t.Parallel()
+
+				err := format.stream(httptest.NewRecorder(), failingSeq(2, errTest))
+				if !errors.Is(err, itermore.ErrStreamStarted) || !errors.Is(err, errTest) {
+					t.Errorf("got %v, want %v wrapped in %v", err, errTest, itermore.ErrStreamStarted)
+				}
+
+				err = format.stream(httptest.NewRecorder(), failingSeq(0, errTest))
+				if errors.Is(err, itermore.ErrStreamStarted) || !errors.Is(err, errTest) {
+					t.Errorf("got %v, want plain %v", err, errTest)
+				}
+			})
+
+			t.Run("early-error", func(t *testing.T) {
+				t.Parallel()
+
+				server := streamServer(t, format.stream, failingSeq(0, errTest))
+
+				resp, err := http.Get(server.URL)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if resp.StatusCode != http.StatusInternalServerError {
+					t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusInternalServerError)
+				}
+
+				if _, err := collectSeqErr(format.decode(resp)); err == nil {
+					t.Error("expected error for unexpected status")
+				}
+			})
+
+			t.Run("encoding-error", func(t *testing.T) {
+				t.Parallel()
+
+				seq := itermore.NoErrors(itermore.Items(json.RawMessage("{")))
+
+				rec := httptest.NewRecorder()
+				var stream func(http.ResponseWriter, iter.Seq2[json.RawMessage, error], ...itermore.StreamOption) error
+				if format.name == "array" {
+					stream = itermore.StreamJSONArray[json.RawMessage]
+				} else {
+					stream = itermore.StreamNDJSON[json.RawMessage]
+				}
+
+				if err := stream(rec, seq); err == nil {
+					t.Error("expected encoding error")
+				}
+
+				if rec.Body.Len() != 0 {
+					t.Errorf("unexpected body %q", rec.Body)
+				}
+			})
+		})
+	}
+}
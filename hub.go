@@ -0,0 +1,336 @@
+package itermore
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"slices"
+	"sync"
+)
+
+// ErrHubClosed is returned by Hub.Publish after the hub is closed.
+var ErrHubClosed = errors.New("hub is closed")
+
+// OverflowPolicy defines, what Hub does, when a subscriber buffer is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes the publisher wait until the subscriber receives the value.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest buffered value to make room for the new one.
+	OverflowDropOldest
+	// OverflowDropNewest drops the new value.
+	OverflowDropNewest
+	// OverflowDisconnect disconnects the subscriber: its sequence ends after buffered values.
+	OverflowDisconnect
+)
+
+// SubscribeOption configures Hub.Subscribe.
+type SubscribeOption func(*subscribeConfig)
+
+type subscribeConfig struct {
+	buffer   int
+	overflow OverflowPolicy
+	topics   []string
+}
+
+// WithBuffer sets the subscriber buffer size, 16 by default.
+// With zero buffer, values are handed over one by one, and drop policies drop values,
+// if the subscriber is not ready to receive them.
+// It will panic if size is negative.
+func WithBuffer(size int) SubscribeOption {
+	if size < 0 {
+		panic("itermore.WithBuffer: size must not be negative")
+	}
+
+	return func(cfg *subscribeConfig) {
+		cfg.buffer = size
+	}
+}
+
+// WithOverflow sets the overflow policy of the subscriber, OverflowBlock by default.
+func WithOverflow(policy OverflowPolicy) SubscribeOption {
+	return func(cfg *subscribeConfig) {
+		cfg.overflow = policy
+	}
+}
+
+// WithTopics makes the subscriber receive only values published to the given topics.
+// By default, the subscriber receives values of all topics.
+func WithTopics(topics ...string) SubscribeOption {
+	topics = slices.Clone(topics)
+
+	return func(cfg *subscribeConfig) {
+		cfg.topics = append(cfg.topics, topics...)
+	}
+}
+
+// Hub is an in-process publish-subscribe hub, which delivers published values to subscriber sequences.
+// The zero value is ready to use. Hub is safe for concurrent use.
+type Hub[E any] struct {
+	mu          sync.Mutex
+	subscribers map[*subscriber[E]]struct{}
+	closed      bool
+	// closing is closed by Close to interrupt pending deliveries, it is created lazily.
+	closing chan struct{}
+}
+
+type subscriber[E any] struct {
+	cfg    *subscribeConfig
+	values chan E
+	// done is closed, when the consumer stops listening.
+	done     chan struct{}
+	doneOnce sync.Once
+
+	// mu is read-locked by deliveries and locked to close values.
+	mu     sync.RWMutex
+	closed bool
+}
+
+func (sub *subscriber[E]) wants(topic string) bool {
+	return len(sub.cfg.topics) == 0 || slices.Contains(sub.cfg.topics, topic)
+}
+
+// close closes the subscriber channel, so its sequence ends after buffered values.
+func (sub *subscriber[E]) close() {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	if !sub.closed {
+		sub.closed = true
+		close(sub.values)
+	}
+}
+
+// Subscribe registers a subscriber and returns a sequence of values published to the hub.
+// Values published after Subscribe returns are delivered, even if the iteration is not started yet.
+// The sequence can be iterated once, it is built on top of ChanCtx. Further iterations yield nothing.
+//
+// The subscription ends, when ctx is canceled, the iteration stops, the subscriber is disconnected by
+// OverflowDisconnect policy, or the hub is closed. Note that a subscriber with OverflowBlock policy,
+// which is not consumed, blocks publishers until ctx is canceled or the hub is closed,
+// other subscribers still receive published values meanwhile.
+func (hub *Hub[E]) Subscribe(ctx context.Context, opts ...SubscribeOption) iter.Seq[E] {
+	cfg := &subscribeConfig{
+		buffer: 16,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	sub := &subscriber[E]{
+		cfg:    cfg,
+		values: make(chan E, cfg.buffer),
+		done:   make(chan struct{}),
+	}
+
+	hub.mu.Lock()
+	if hub.closed {
+		sub.close()
+	} else {
+		if hub.subscribers == nil {
+			hub.subscribers = map[*subscriber[E]]struct{}{}
+		}
+		hub.subscribers[sub] = struct{}{}
+	}
+	hub.mu.Unlock()
+
+	stopAfter := context.AfterFunc(ctx, func() { hub.unsubscribe(sub) })
+
+	return func(yield func(E) bool) {
+		select {
+		case <-sub.done:
+			// the subscription is already over, values channel may be left open
+			return
+		default:
+		}
+
+		defer hub.unsubscribe(sub)
+		defer stopAfter()
+
+		for value := range ChanCtx(ctx, sub.values) {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+func (hub *Hub[E]) unsubscribe(sub *subscriber[E]) {
+	sub.doneOnce.Do(func() { close(sub.done) })
+
+	hub.mu.Lock()
+	delete(hub.subscribers, sub)
+	hub.mu.Unlock()
+}
+
+// closingChan returns the channel, which is closed by Close, it must be called with mu locked.
+func (hub *Hub[E]) closingChan() chan struct{} {
+	if hub.closing == nil {
+		hub.closing = make(chan struct{})
+	}
+
+	return hub.closing
+}
+
+// Publish delivers the value to subscribers of the topic according to their overflow policies.
+// Subscribers are served independently: a subscriber with OverflowBlock policy, which is not ready,
+// delays only its own delivery, while Publish waits for it.
+// It returns ctx error, if ctx is canceled while waiting for a blocking subscriber,
+// and ErrHubClosed, if the hub is closed.
+func (hub *Hub[E]) Publish(ctx context.Context, topic string, value E) error {
+	hub.mu.Lock()
+	if hub.closed {
+		hub.mu.Unlock()
+		return ErrHubClosed
+	}
+
+	subscribers := make([]*subscriber[E], 0, len(hub.subscribers))
+	for sub := range hub.subscribers {
+		if sub.wants(topic) {
+			subscribers = append(subscribers, sub)
+		}
+	}
+	closing := hub.closingChan()
+	hub.mu.Unlock()
+
+	var pending []*subscriber[E]
+	for _, sub := range subscribers {
+		delivered, overflow := sub.offer(value)
+		if overflow {
+			hub.disconnect(sub)
+		}
+		if !delivered {
+			pending = append(pending, sub)
+		}
+	}
+
+	switch len(pending) {
+	case 0:
+		return nil
+	case 1:
+		return hub.wait(ctx, closing, pending[0], value)
+	}
+
+	errs := make([]error, len(pending))
+	wg := &sync.WaitGroup{}
+	for i, sub := range pending {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = hub.wait(ctx, closing, sub, value)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// offer delivers the value without waiting.
+// It returns false, if the subscriber has OverflowBlock policy and is not ready to receive the value,
+// and reports overflow, if the subscriber must be disconnected by OverflowDisconnect policy.
+func (sub *subscriber[E]) offer(value E) (delivered, overflow bool) {
+	sub.mu.RLock()
+	defer sub.mu.RUnlock()
+
+	if sub.closed {
+		return true, false
+	}
+
+	switch sub.cfg.overflow {
+	case OverflowDropNewest:
+		select {
+		case sub.values <- value:
+		default:
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case sub.values <- value:
+				return true, false
+			default:
+			}
+
+			select {
+			case <-sub.values:
+				// the oldest value is dropped
+			default:
+				if cap(sub.values) == 0 {
+					return true, false
+				}
+			}
+		}
+	case OverflowDisconnect:
+		select {
+		case sub.values <- value:
+		default:
+			return true, true
+		}
+	default:
+		select {
+		case sub.values <- value:
+		default:
+			return false, false
+		}
+	}
+
+	return true, false
+}
+
+// wait delivers the value to the subscriber with OverflowBlock policy, waiting until it is ready.
+func (hub *Hub[E]) wait(ctx context.Context, closing <-chan struct{}, sub *subscriber[E], value E) error {
+	sub.mu.RLock()
+	defer sub.mu.RUnlock()
+
+	if sub.closed {
+		return nil
+	}
+
+	select {
+	case sub.values <- value:
+		return nil
+	case <-sub.done:
+		return nil
+	case <-closing:
+		return ErrHubClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (hub *Hub[E]) disconnect(sub *subscriber[E]) {
+	hub.mu.Lock()
+	delete(hub.subscribers, sub)
+	hub.mu.Unlock()
+
+	sub.close()
+}
+
+// Close closes the hub: subscriber sequences end after buffered values, following publishing fails with ErrHubClosed.
+// Pending Publish calls, which wait for blocking subscribers, are interrupted and return ErrHubClosed.
+func (hub *Hub[E]) Close() error {
+	hub.mu.Lock()
+	if hub.closed {
+		hub.mu.Unlock()
+		return nil
+	}
+
+	hub.closed = true
+	close(hub.closingChan())
+	subscribers := hub.subscribers
+	hub.subscribers = nil
+	hub.mu.Unlock()
+
+	for sub := range subscribers {
+		sub.close()
+	}
+
+	return nil
+}
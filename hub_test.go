@@ -0,0 +1,253 @@
+package itermore_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ninedraft/itermore"
+)
+
+func ExampleHub() {
+	ctx := context.Background()
+	hub := &itermore.Hub[string]{}
+
+	news := hub.Subscribe(ctx, itermore.WithTopics("news"))
+
+	_ = hub.Publish(ctx, "news", "hello")
+	_ = hub.Publish(ctx, "weather", "sunny")
+	_ = hub.Publish(ctx, "news", "world")
+	_ = hub.Close()
+
+	for msg := range news {
+		fmt.Println(msg)
+	}
+	// Output: hello
+	// world
+}
+
+// publishAll publishes values to the topic.
+func publishAll(t *testing.T, hub *itermore.Hub[int], topic string, values ...int) {
+	t.Helper()
+
+	for _, value := range values {
+		if err := hub.Publish(context.Background(), topic, value); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+}
+
+func TestHub(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("fan-out", func(t *testing.T) {
+		t.Parallel()
+
+		hub := &itermore.Hub[int]{}
+		all := hub.Subscribe(ctx, itermore.WithBuffer(0))
+		odd := hub.Subscribe(ctx, itermore.WithTopics("odd"), itermore.WithBuffer(0))
+
+		wg := &sync.WaitGroup{}
+		results := make([][]int, 2)
+		for i, sub := range []iter.Seq[int]{all, odd} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i] = slices.Collect(sub)
+			}()
+		}
+
+		for i := range 6 {
+			topic := "even"
+			if i%2 == 1 {
+				topic = "odd"
+			}
+			if err := hub.Publish(ctx, topic, i); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		_ = hub.Close()
+		wg.Wait()
+
+		if want := []int{0, 1, 2, 3, 4, 5}; !slices.Equal(results[0], want) {
+			t.Errorf("all: got %v, want %v", results[0], want)
+		}
+
+		if want := []int{1, 3, 5}; !slices.Equal(results[1], want) {
+			t.Errorf("odd: got %v, want %v", results[1], want)
+		}
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		t.Parallel()
+
+		tests := []struct {
+			policy itermore.OverflowPolicy
+			want   []int
+		}{
+			{policy: itermore.OverflowDropOldest, want: []int{3, 4}},
+			{policy: itermore.OverflowDropNewest, want: []int{0, 1}},
+			{policy: itermore.OverflowDisconnect, want: []int{0, 1}},
+		}
+
+		for _, tc := range tests {
+			hub := &itermore.Hub[int]{}
+			seq := hub.Subscribe(ctx, itermore.WithBuffer(2), itermore.WithOverflow(tc.policy))
+
+			publishAll(t, hub, "", 0, 1, 2, 3, 4)
+
+			if tc.policy != itermore.OverflowDisconnect {
+				_ = hub.Close()
+			}
+
+			if got := slices.Collect(seq); !slices.Equal(got, tc.want) {
+				t.Errorf("policy %d: got %v, want %v", tc.policy, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("block", func(t *testing.T) {
+		t.Parallel()
+
+		hub := &itermore.Hub[int]{}
+		_ = hub.Subscribe(ctx, itermore.WithBuffer(1))
+
+		if err := hub.Publish(ctx, "", 1); err != nil {
+			t.Fatal(err)
+		}
+
+		timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+
+		if err := hub.Publish(timeout, "", 2); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+
+	t.Run("stalled-subscriber", func(t *testing.T) {
+		t.Parallel()
+
+		hub := &itermore.Hub[int]{}
+		// the stalled subscriber is never consumed
+		_ = hub.Subscribe(ctx, itermore.WithBuffer(0))
+		live := hub.Subscribe(ctx, itermore.WithBuffer(0))
+
+		publishErr := make(chan error, 1)
+		go func() { publishErr <- hub.Publish(ctx, "", 1) }()
+
+		received := make(chan int)
+		liveDone := make(chan struct{})
+		go func() {
+			defer close(liveDone)
+			for value := range live {
+				received <- value
+			}
+		}()
+
+		select {
+		case value := <-received:
+			if value != 1 {
+				t.Errorf("got %d, want %d", value, 1)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("live subscriber is blocked by the stalled one")
+		}
+
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			_ = hub.Close()
+		}()
+
+		for name, done := range map[string]<-chan struct{}{"Close": closed, "live subscriber": liveDone} {
+			select {
+			case <-done:
+			case <-time.After(5 * time.Second):
+				t.Fatalf("%s hangs on the stalled subscriber", name)
+			}
+		}
+
+		if err := <-publishErr; !errors.Is(err, itermore.ErrHubClosed) {
+			t.Errorf("got %v, want %v", err, itermore.ErrHubClosed)
+		}
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		t.Parallel()
+
+		hub := &itermore.Hub[int]{}
+
+		subCtx, cancel := context.WithCancel(ctx)
+		canceled := hub.Subscribe(subCtx, itermore.WithBuffer(0))
+		cancel()
+
+		stopped := hub.Subscribe(ctx, itermore.WithBuffer(0))
+		published := make(chan struct{})
+		go func() {
+			defer close(published)
+			publishAll(t, hub, "", 1, 2, 3)
+		}()
+
+		for range stopped {
+			break
+		}
+		<-published
+
+		// publishing must not block on the canceled and stopped subscribers
+		publishAll(t, hub, "", 4, 5, 6)
+
+		for range canceled {
+			t.Error("unexpected value of canceled subscriber")
+		}
+	})
+
+	t.Run("iterate-twice", func(t *testing.T) {
+		t.Parallel()
+
+		hub := &itermore.Hub[int]{}
+		seq := hub.Subscribe(ctx)
+		publishAll(t, hub, "", 1, 2)
+
+		for range seq {
+			break
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for value := range seq {
+				t.Errorf("unexpected value %d of stopped subscriber", value)
+			}
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("second iteration of the subscriber sequence hangs")
+		}
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		t.Parallel()
+
+		hub := &itermore.Hub[int]{}
+		_ = hub.Close()
+
+		if err := hub.Publish(ctx, "", 1); !errors.Is(err, itermore.ErrHubClosed) {
+			t.Errorf("got %v, want %v", err, itermore.ErrHubClosed)
+		}
+
+		for range hub.Subscribe(ctx) {
+			t.Error("unexpected value of closed hub")
+		}
+	})
+}
@@ -3,6 +3,7 @@ package itermore
 import (
 	"cmp"
 	"iter"
+	"sync"
 	"sync/atomic"
 
 	"golang.org/x/exp/constraints"
@@ -52,6 +53,17 @@ func Forever[E any](value E) iter.Seq[E] {
 	}
 }
 
+// Forever2 creates an infinite sequence that yields a single pair.
+func Forever2[A, B any](a A, b B) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		for {
+			if !yield(a, b) {
+				return
+			}
+		}
+	}
+}
+
 // ForeverFn creates an infinite sequence that yields a single value from the given function.
 func ForeverFn[E any](fn func() E) iter.Seq[E] {
 	return func(yield func(E) bool) {
@@ -78,6 +90,18 @@ func Enumerate[E any](seq iter.Seq[E]) iter.Seq2[int, E] {
 	}
 }
 
+// NoErrors creates an error-aware sequence that yields values from the given sequence with nil errors.
+// It allows to pass plain sequences to functions, which accept iter.Seq2[E, error], such as StreamJSONArray.
+func NoErrors[E any](seq iter.Seq[E]) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		for v := range seq {
+			if !yield(v, nil) {
+				return
+			}
+		}
+	}
+}
+
 // Next creates sequence that yields values from the given function.
 func Next[E any](next func() (E, bool)) iter.Seq[E] {
 	isDrained := &atomic.Bool{}
@@ -203,6 +227,32 @@ func SkipN[E any](n int, seq iter.Seq[E]) iter.Seq[E] {
 	}
 }
 
+// SkipN2 skips first n pairs from the given sequence.
+// It is similar to SkipN, but works with sequences of pairs.
+func SkipN2[A, B any](n int, seq iter.Seq2[A, B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		next, cancel := iter.Pull2(seq)
+		defer cancel()
+
+		for i := 0; i < n; i++ {
+			_, _, ok := next()
+			if !ok {
+				return
+			}
+		}
+
+		for {
+			a, b, ok := next()
+			if !ok {
+				return
+			}
+			if !yield(a, b) {
+				return
+			}
+		}
+	}
+}
+
 // TakeN yields first n values from the given sequence.
 // If n is greater then number of values in the sequence, TakeN will yield all values from the sequence.
 // If n is negative or zero, TakeN will return an empty sequence.
@@ -223,6 +273,25 @@ func TakeN[E any](n int, seq iter.Seq[E]) iter.Seq[E] {
 	}
 }
 
+// TakeN2 yields first n pairs from the given sequence.
+// It is similar to TakeN, but works with sequences of pairs.
+func TakeN2[A, B any](n int, seq iter.Seq2[A, B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		next, cancel := iter.Pull2(seq)
+		defer cancel()
+
+		for i := 0; i < n; i++ {
+			a, b, ok := next()
+			if !ok {
+				return
+			}
+			if !yield(a, b) {
+				return
+			}
+		}
+	}
+}
+
 // Number is a type, which can be added and compared.
 type Number interface {
 	constraints.Integer | constraints.Float
@@ -292,6 +361,15 @@ func Then[E any](seq iter.Seq[E], then func()) iter.Seq[E] {
 	}
 }
 
+// Then2 returns a sequence, which yields pairs from seq, then always calls then function.
+// It calls then function even if seq is empty.
+func Then2[A, B any](seq iter.Seq2[A, B], then func()) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		defer then()
+		YieldFrom2(yield, seq)
+	}
+}
+
 // Compact returns a sequence, which yields values from seq, but skips consecutive duplicates.
 // It roughly equal to slices.Compact.
 func Compact[E comparable](seq iter.Seq[E]) iter.Seq[E] {
@@ -310,6 +388,67 @@ func Compact[E comparable](seq iter.Seq[E]) iter.Seq[E] {
 	}
 }
 
+// Compact2 returns a sequence, which yields pairs from seq, but skips consecutive duplicates.
+// Pairs are equal if both of their values are equal.
+func Compact2[A, B comparable](seq iter.Seq2[A, B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		var prevA A
+		var prevB B
+		ok := false
+		for a, b := range seq {
+			if ok && prevA == a && prevB == b {
+				continue
+			}
+			prevA, prevB, ok = a, b, true
+			if !yield(a, b) {
+				return
+			}
+		}
+	}
+}
+
+// CompactFunc is like Compact, but uses an equality function to compare values.
+// It roughly equal to slices.CompactFunc.
+func CompactFunc[E any](seq iter.Seq[E], eq func(a, b E) bool) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		var prev E
+		ok := false
+		for value := range seq {
+			if ok && eq(prev, value) {
+				continue
+			}
+			prev, ok = value, true
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Filter returns a sequence, which yields only values from seq that satisfy the given predicate.
+func Filter[E any](seq iter.Seq[E], pred func(E) bool) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for value := range seq {
+			if !pred(value) {
+				continue
+			}
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+// Count consumes the sequence and returns number of values in it.
+func Count[E any](seq iter.Seq[E]) int {
+	n := 0
+	for range seq {
+		n++
+	}
+
+	return n
+}
+
 // Max returns the largest element in the sequence.
 // If sequence is empty, Max returns false.
 // If there is a single item in the sequence, Max returns it.
@@ -346,6 +485,91 @@ func Min[E cmp.Ordered](seq iter.Seq[E]) (E, bool) {
 	return x, ok
 }
 
+// MaxFunc returns the largest element in the sequence, using cmp to compare elements.
+// If there is more than one maximal element according to cmp, MaxFunc returns the first one.
+// If sequence is empty, MaxFunc returns false.
+// It roughly equal to slices.MaxFunc.
+func MaxFunc[E any](seq iter.Seq[E], cmp func(a, b E) int) (E, bool) {
+	var x E
+	ok := false
+
+	for value := range seq {
+		if !ok || cmp(value, x) > 0 {
+			x, ok = value, true
+		}
+	}
+
+	return x, ok
+}
+
+// MinFunc returns the smallest element in the sequence, using cmp to compare elements.
+// If there is more than one minimal element according to cmp, MinFunc returns the first one.
+// If sequence is empty, MinFunc returns false.
+// It roughly equal to slices.MinFunc.
+func MinFunc[E any](seq iter.Seq[E], cmp func(a, b E) int) (E, bool) {
+	var x E
+	ok := false
+
+	for value := range seq {
+		if !ok || cmp(value, x) < 0 {
+			x, ok = value, true
+		}
+	}
+
+	return x, ok
+}
+
+// MaxKey returns the pair with the largest key in the sequence.
+// If there is more than one pair with maximal key, MaxKey returns the first one.
+// If sequence is empty, MaxKey returns false.
+func MaxKey[K cmp.Ordered, V any](seq iter.Seq2[K, V]) (K, V, bool) {
+	return bestPair(seq, func(k, bestK K, _, _ V) bool {
+		return cmp.Less(bestK, k)
+	})
+}
+
+// MinKey returns the pair with the smallest key in the sequence.
+// If there is more than one pair with minimal key, MinKey returns the first one.
+// If sequence is empty, MinKey returns false.
+func MinKey[K cmp.Ordered, V any](seq iter.Seq2[K, V]) (K, V, bool) {
+	return bestPair(seq, func(k, bestK K, _, _ V) bool {
+		return cmp.Less(k, bestK)
+	})
+}
+
+// MaxValue returns the pair with the largest value in the sequence.
+// If there is more than one pair with maximal value, MaxValue returns the first one.
+// If sequence is empty, MaxValue returns false.
+func MaxValue[K any, V cmp.Ordered](seq iter.Seq2[K, V]) (K, V, bool) {
+	return bestPair(seq, func(_, _ K, v, bestV V) bool {
+		return cmp.Less(bestV, v)
+	})
+}
+
+// MinValue returns the pair with the smallest value in the sequence.
+// If there is more than one pair with minimal value, MinValue returns the first one.
+// If sequence is empty, MinValue returns false.
+func MinValue[K any, V cmp.Ordered](seq iter.Seq2[K, V]) (K, V, bool) {
+	return bestPair(seq, func(_, _ K, v, bestV V) bool {
+		return cmp.Less(v, bestV)
+	})
+}
+
+// bestPair returns the first pair, for which no following pair is better.
+func bestPair[K, V any](seq iter.Seq2[K, V], better func(k, bestK K, v, bestV V) bool) (K, V, bool) {
+	var bestK K
+	var bestV V
+	ok := false
+
+	for k, v := range seq {
+		if !ok || better(k, bestK, v, bestV) {
+			bestK, bestV, ok = k, v, true
+		}
+	}
+
+	return bestK, bestV, ok
+}
+
 // PairsPadded creates a sequence that yields pairs of values from the given sequence.
 // If number of values in the sequence is odd, Pairs will pad the last pair with the given value.
 func PairsPadded[E any](seq iter.Seq[E], pad E) iter.Seq2[E, E] {
@@ -426,3 +650,114 @@ func Drain[E any](seq iter.Seq[E]) {
 	for range seq {
 	}
 }
+
+// Tee returns n independent sequences, which yield the same values as seq.
+// The source sequence is iterated only once: values pulled by one of the returned sequences
+// are buffered until all other sequences yield them, so memory usage depends on how far
+// the returned sequences diverge.
+//
+// Each returned sequence can be iterated only once. The source is stopped after all
+// returned sequences are stopped or drained.
+// Returned sequences can be consumed from different goroutines.
+// If n is negative or zero, Tee returns nil.
+func Tee[E any](seq iter.Seq[E], n int) []iter.Seq[E] {
+	if n <= 0 {
+		return nil
+	}
+
+	state := &tee[E]{
+		seq:    seq,
+		queues: make([][]E, n),
+		done:   make([]bool, n),
+		active: n,
+	}
+
+	seqs := make([]iter.Seq[E], n)
+	for i := range seqs {
+		seqs[i] = func(yield func(E) bool) {
+			defer state.release(i)
+
+			for {
+				value, ok := state.pull(i)
+				if !ok {
+					return
+				}
+				if !yield(value) {
+					return
+				}
+			}
+		}
+	}
+
+	return seqs
+}
+
+type tee[E any] struct {
+	mu      sync.Mutex
+	seq     iter.Seq[E]
+	next    func() (E, bool)
+	stop    func()
+	drained bool
+	queues  [][]E
+	done    []bool
+	active  int
+}
+
+func (t *tee[E]) pull(i int) (E, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var empty E
+
+	if t.done[i] {
+		return empty, false
+	}
+
+	if queue := t.queues[i]; len(queue) > 0 {
+		value := queue[0]
+		queue[0] = empty // help GC
+		t.queues[i] = queue[1:]
+
+		return value, true
+	}
+
+	if t.drained {
+		return empty, false
+	}
+
+	if t.next == nil {
+		t.next, t.stop = iter.Pull(t.seq)
+	}
+
+	value, ok := t.next()
+	if !ok {
+		t.drained = true
+		t.stop()
+		return empty, false
+	}
+
+	for j, done := range t.done {
+		if j != i && !done {
+			t.queues[j] = append(t.queues[j], value)
+		}
+	}
+
+	return value, true
+}
+
+func (t *tee[E]) release(i int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.done[i] {
+		return
+	}
+
+	t.done[i] = true
+	t.queues[i] = nil
+	t.active--
+
+	if t.active == 0 && t.stop != nil {
+		t.stop()
+	}
+}
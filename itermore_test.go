@@ -1,9 +1,11 @@
 package itermore_test
 
 import (
+	"cmp"
 	"fmt"
 	"iter"
 	"slices"
+	"strings"
 	"testing"
 
 	"github.com/ninedraft/itermore"
@@ -301,6 +303,22 @@ func TestEnumerate(t *testing.T) {
 	})
 }
 
+func TestNoErrors(t *testing.T) {
+	t.Parallel()
+
+	assertBreak2(t, itermore.NoErrors(itermore.Items(1, 2, 3)))
+
+	got, err := collectSeqErr(itermore.NoErrors(itermore.Items(1, 2, 3)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("got:  %v", got)
+		t.Errorf("want: %v", want)
+	}
+}
+
 func TestNext(t *testing.T) {
 	t.Parallel()
 
@@ -857,6 +875,270 @@ func TestPairs(t *testing.T) {
 	})
 }
 
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	isEven := func(x int) bool { return x%2 == 0 }
+
+	assertBreak(t, itermore.Filter(itermore.Items(2, 4), isEven))
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		seq := itermore.Items(1, 2, 3, 4, 5, 6)
+		got := slices.Collect(itermore.Filter(seq, isEven))
+
+		want := []int{2, 4, 6}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+}
+
+func TestCount(t *testing.T) {
+	t.Parallel()
+
+	if got := itermore.Count(itermore.Items(1, 2, 3)); got != 3 {
+		t.Errorf("got %d, want %d", got, 3)
+	}
+
+	if got := itermore.Count(itermore.None[int]); got != 0 {
+		t.Errorf("got %d, want %d", got, 0)
+	}
+}
+
+func TestCompactFunc(t *testing.T) {
+	t.Parallel()
+
+	eq := strings.EqualFold
+
+	assertBreak(t, itermore.CompactFunc(itermore.Items("a", "A"), eq))
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		seq := itermore.Items("a", "A", "b", "c", "C", "c", "a")
+		got := slices.Collect(itermore.CompactFunc(seq, eq))
+
+		want := []string{"a", "b", "c", "a"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+}
+
+func TestMaxMinFunc(t *testing.T) {
+	t.Parallel()
+
+	byLen := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+	seq := itermore.Items("bb", "a", "cc", "d")
+
+	if got, ok := itermore.MaxFunc(seq, byLen); !ok || got != "bb" {
+		t.Errorf("MaxFunc: got %q, %v; want %q, true", got, ok, "bb")
+	}
+
+	if got, ok := itermore.MinFunc(seq, byLen); !ok || got != "a" {
+		t.Errorf("MinFunc: got %q, %v; want %q, true", got, ok, "a")
+	}
+
+	if _, ok := itermore.MaxFunc(itermore.None[string], byLen); ok {
+		t.Errorf("MaxFunc: empty seq must return false")
+	}
+}
+
+func TestTee(t *testing.T) {
+	t.Parallel()
+
+	{
+		seqs := itermore.Tee(itermore.Items(1, 2, 3), 2)
+		assertBreak(t, seqs[0])
+		assertBreak(t, seqs[1])
+	}
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		pulled := 0
+		source := func(yield func(int) bool) {
+			for i := range 5 {
+				pulled++
+				if !yield(i) {
+					return
+				}
+			}
+		}
+
+		seqs := itermore.Tee(source, 3)
+
+		want := []int{0, 1, 2, 3, 4}
+		for i, seq := range seqs {
+			got := slices.Collect(seq)
+			if !slices.Equal(got, want) {
+				t.Errorf("%d got:  %v", i, got)
+				t.Errorf("%d want: %v", i, want)
+			}
+		}
+
+		if pulled != len(want) {
+			t.Errorf("source must be iterated once, pulled %d values", pulled)
+		}
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		t.Parallel()
+
+		stopped := false
+		source := itermore.Then(itermore.Items(1, 2, 3), func() { stopped = true })
+
+		seqs := itermore.Tee(source, 2)
+
+		for range seqs[0] {
+			break
+		}
+		if stopped {
+			t.Fatal("source must not be stopped while other sequences are active")
+		}
+
+		got := slices.Collect(seqs[1])
+		want := []int{1, 2, 3}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+
+		if !stopped {
+			t.Fatal("source must be stopped")
+		}
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		t.Parallel()
+
+		if seqs := itermore.Tee(itermore.Items(1), 0); seqs != nil {
+			t.Fatalf("got %v, want nil", seqs)
+		}
+	})
+}
+
+func TestSeq2Helpers(t *testing.T) {
+	t.Parallel()
+
+	newSeq := func() iter.Seq2[int, string] {
+		return itermore.Enumerate(itermore.Items("a", "b", "c", "d"))
+	}
+
+	collect := func(seq iter.Seq2[int, string]) []pair {
+		got := []pair{}
+		for a, b := range seq {
+			got = append(got, pair{a, b})
+		}
+		return got
+	}
+
+	assertBreak2(t, itermore.SkipN2(1, newSeq()))
+	assertBreak2(t, itermore.TakeN2(2, newSeq()))
+	assertBreak2(t, itermore.Compact2(newSeq()))
+	assertBreak2(t, itermore.Then2(newSeq(), func() {}))
+	assertBreak2(t, itermore.Forever2(1, "a"))
+
+	t.Run("skip", func(t *testing.T) {
+		t.Parallel()
+
+		got := collect(itermore.SkipN2(2, newSeq()))
+		want := []pair{{2, "c"}, {3, "d"}}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+
+		if got := collect(itermore.SkipN2(10, newSeq())); len(got) != 0 {
+			t.Errorf("got %v, want empty", got)
+		}
+	})
+
+	t.Run("take", func(t *testing.T) {
+		t.Parallel()
+
+		got := collect(itermore.TakeN2(2, newSeq()))
+		want := []pair{{0, "a"}, {1, "b"}}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+
+		if got := collect(itermore.TakeN2(0, newSeq())); len(got) != 0 {
+			t.Errorf("got %v, want empty", got)
+		}
+	})
+
+	t.Run("compact", func(t *testing.T) {
+		t.Parallel()
+
+		input := []itermore.Pair[int, string]{{1, "a"}, {1, "a"}, {1, "b"}, {2, "b"}, {2, "b"}}
+		got := collect(itermore.Compact2(itermore.FromPairs(itermore.Slice(input))))
+
+		want := []pair{{1, "a"}, {1, "b"}, {2, "b"}}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("then", func(t *testing.T) {
+		t.Parallel()
+
+		called := false
+		got := collect(itermore.Then2(newSeq(), func() { called = true }))
+
+		if len(got) != 4 || !called {
+			t.Errorf("got %v, called %v", got, called)
+		}
+	})
+
+	t.Run("forever", func(t *testing.T) {
+		t.Parallel()
+
+		got := collect(itermore.TakeN2(3, itermore.Forever2(1, "a")))
+		want := []pair{{1, "a"}, {1, "a"}, {1, "a"}}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+}
+
+func TestMaxMinPairs(t *testing.T) {
+	t.Parallel()
+
+	input := []itermore.Pair[string, int]{{"b", 3}, {"a", 1}, {"c", 1}, {"c", 3}}
+	seq := itermore.FromPairs(itermore.Slice(input))
+
+	check := func(name string, k string, v int, ok bool, want itermore.Pair[string, int]) {
+		t.Helper()
+		if !ok || k != want.A || v != want.B {
+			t.Errorf("%s: got %q, %d, %v; want %v", name, k, v, ok, want)
+		}
+	}
+
+	k, v, ok := itermore.MaxKey(seq)
+	check("MaxKey", k, v, ok, itermore.PairOf("c", 1))
+
+	k, v, ok = itermore.MinKey(seq)
+	check("MinKey", k, v, ok, itermore.PairOf("a", 1))
+
+	k, v, ok = itermore.MaxValue(seq)
+	check("MaxValue", k, v, ok, itermore.PairOf("b", 3))
+
+	k, v, ok = itermore.MinValue(seq)
+	check("MinValue", k, v, ok, itermore.PairOf("a", 1))
+
+	if _, _, ok := itermore.MaxKey(itermore.None2[string, int]); ok {
+		t.Error("MaxKey: empty seq must return false")
+	}
+}
+
 func assertBreak[E any](t *testing.T, seq iter.Seq[E]) {
 	t.Helper()
 
@@ -0,0 +1,121 @@
+package itermore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+)
+
+// LineError is an error annotated with a line number of the input.
+type LineError struct {
+	// Line is a 1-based line number.
+	Line int
+	Err  error
+}
+
+func (le *LineError) Error() string {
+	return fmt.Sprintf("line %d: %v", le.Line, le.Err)
+}
+
+func (le *LineError) Unwrap() error {
+	return le.Err
+}
+
+// DecodeJSONLines creates a sequence that yields values decoded from the given NDJSON (JSON Lines) stream.
+// Each non-blank line must contain a single JSON value. Blank lines are skipped.
+// Scan options can be used to limit the line size.
+//
+// The sequence is error-aware: if reading or decoding fails, it yields the error with a zero value as its last pair.
+// Errors are reported as *LineError with the number of the failed line.
+func DecodeJSONLines[T any](re io.Reader, opts ...ScanOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var empty T
+
+		lineNo := 0
+		for line, err := range LinesBytes(re, opts...) {
+			lineNo++
+
+			if err != nil {
+				yield(empty, &LineError{Line: lineNo, Err: err})
+				return
+			}
+
+			if len(bytes.TrimSpace(line)) == 0 {
+				continue
+			}
+
+			var value T
+			if err := json.Unmarshal(line, &value); err != nil {
+				yield(empty, &LineError{Line: lineNo, Err: err})
+				return
+			}
+
+			if !yield(value, nil) {
+				return
+			}
+		}
+	}
+}
+
+// EncodeJSONLines writes values from the given sequence to the writer as NDJSON (JSON Lines) stream.
+// Each value is written as a single line.
+// It returns the first encoding or writing error.
+func EncodeJSONLines[T any](wr io.Writer, seq iter.Seq[T]) error {
+	enc := json.NewEncoder(wr)
+
+	for value := range seq {
+		if err := enc.Encode(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DecodeJSONArray creates a sequence that yields elements of a top-level JSON array from the given reader.
+// Elements are decoded one by one, so the whole array is never loaded into memory.
+//
+// The sequence is error-aware: if reading or decoding fails, it yields the error with a zero value as its last pair.
+func DecodeJSONArray[T any](re io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var empty T
+
+		dec := json.NewDecoder(re)
+
+		if err := expectDelim(dec, '['); err != nil {
+			yield(empty, err)
+			return
+		}
+
+		for i := 0; dec.More(); i++ {
+			var value T
+			if err := dec.Decode(&value); err != nil {
+				yield(empty, fmt.Errorf("decoding array element %d: %w", i, noEOF(err)))
+				return
+			}
+
+			if !yield(value, nil) {
+				return
+			}
+		}
+
+		if err := expectDelim(dec, ']'); err != nil {
+			yield(empty, err)
+		}
+	}
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("expecting %q: %w", delim, noEOF(err))
+	}
+
+	if token != delim {
+		return fmt.Errorf("expecting %q, got %v", delim, token)
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package itermore_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ninedraft/itermore"
+)
+
+type event struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func ExampleDecodeJSONLines() {
+	input := strings.NewReader(`{"id": 1, "name": "start"}
+{"id": 2, "name": "stop"}
+`)
+
+	for ev, err := range itermore.DecodeJSONLines[event](input) {
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(ev.ID, ev.Name)
+	}
+	// Output: 1 start
+	// 2 stop
+}
+
+func TestDecodeJSONLines(t *testing.T) {
+	t.Parallel()
+
+	assertBreak2(t, itermore.DecodeJSONLines[event](strings.NewReader("{}\n{}")))
+
+	t.Run("round-trip", func(t *testing.T) {
+		t.Parallel()
+
+		want := []event{{1, "a"}, {2, "b\nc"}, {3, ""}}
+
+		buf := &bytes.Buffer{}
+		if err := itermore.EncodeJSONLines(buf, itermore.Slice(want)); err != nil {
+			t.Fatal(err)
+		}
+
+		if lines := strings.Count(buf.String(), "\n"); lines != len(want) {
+			t.Errorf("got %d lines, want %d", lines, len(want))
+		}
+
+		got, err := collectSeqErr(itermore.DecodeJSONLines[event](buf))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("blank-lines", func(t *testing.T) {
+		t.Parallel()
+
+		input := strings.NewReader("\n1\n  \r\n2\n\n")
+		got, err := collectSeqErr(itermore.DecodeJSONLines[int](input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := []int{1, 2}; !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("syntax-error", func(t *testing.T) {
+		t.Parallel()
+
+		input := strings.NewReader("1\n\n{oops}\n3")
+		got, err := collectSeqErr(itermore.DecodeJSONLines[int](input))
+
+		var lineErr *itermore.LineError
+		if !errors.As(err, &lineErr) {
+			t.Fatalf("got %v, want *itermore.LineError", err)
+		}
+
+		if lineErr.Line != 3 {
+			t.Errorf("got line %d, want %d", lineErr.Line, 3)
+		}
+
+		var syntaxErr *json.SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("got %v, want *json.SyntaxError", err)
+		}
+
+		if want := []int{1}; !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("too-long", func(t *testing.T) {
+		t.Parallel()
+
+		input := strings.NewReader("1\n" + strings.Repeat("2", 100))
+		_, err := collectSeqErr(itermore.DecodeJSONLines[int](input, itermore.WithMaxTokenSize(10)))
+
+		if !errors.Is(err, bufio.ErrTooLong) {
+			t.Fatalf("got %v, want %v", err, bufio.ErrTooLong)
+		}
+	})
+}
+
+func TestEncodeJSONLines(t *testing.T) {
+	t.Parallel()
+
+	errTest := errors.New("test error")
+
+	err := itermore.EncodeJSONLines(&errWriter{err: errTest}, itermore.Items(1, 2))
+	if !errors.Is(err, errTest) {
+		t.Fatalf("got %v, want %v", err, errTest)
+	}
+}
+
+func ExampleDecodeJSONArray() {
+	input := strings.NewReader(`[{"id": 1, "name": "start"}, {"id": 2, "name": "stop"}]`)
+
+	for ev, err := range itermore.DecodeJSONArray[event](input) {
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(ev.ID, ev.Name)
+	}
+	// Output: 1 start
+	// 2 stop
+}
+
+func TestDecodeJSONArray(t *testing.T) {
+	t.Parallel()
+
+	assertBreak2(t, itermore.DecodeJSONArray[int](strings.NewReader("[1, 2]")))
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := collectSeqErr(itermore.DecodeJSONArray[int](strings.NewReader(" [1, 2 ,3] ")))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := collectSeqErr(itermore.DecodeJSONArray[int](strings.NewReader("[]")))
+		if err != nil || len(got) != 0 {
+			t.Fatalf("got %v, %v; want empty", got, err)
+		}
+	})
+
+	t.Run("streaming", func(t *testing.T) {
+		t.Parallel()
+
+		// the array is never terminated, but first elements are still decoded
+		pr, pw := io.Pipe()
+		go func() {
+			_, _ = io.WriteString(pw, "[1, 2, ")
+		}()
+		t.Cleanup(func() { _ = pw.Close() })
+
+		for value, err := range itermore.DecodeJSONArray[int](pr) {
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value == 2 {
+				break
+			}
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		for _, input := range []string{`{"a": 1}`, `[1, "x"]`, `[1, 2`, ``} {
+			got, err := collectSeqErr(itermore.DecodeJSONArray[int](strings.NewReader(input)))
+			if err == nil {
+				t.Errorf("%q: expected error, got %v", input, got)
+			}
+		}
+	})
+}
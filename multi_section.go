@@ -0,0 +1,268 @@
+package itermore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"sync"
+)
+
+// SizedReaderAt is a random access reader with known size.
+// It is implemented by *io.SectionReader, *bytes.Reader and *strings.Reader.
+type SizedReaderAt interface {
+	io.ReaderAt
+	Size() int64
+}
+
+// MultiReaderAt returns a MultiSectionReader, which is the logical concatenation of parts from the given sequence.
+// Parts are pulled from the sequence lazily, only when data at their offsets is requested.
+// If the parts implement io.Closer, they are closed by MultiSectionReader.Close.
+func MultiReaderAt[R SizedReaderAt](parts iter.Seq[R]) *MultiSectionReader {
+	return newMultiSectionReader(func(yield func(sectionPart, error) bool) {
+		for part := range parts {
+			if !yield(sectionPart{ReaderAt: part, size: part.Size(), origin: part}, nil) {
+				return
+			}
+		}
+	})
+}
+
+// MultiReadSeeker returns a MultiSectionReader, which is the logical concatenation of parts from the given sequence.
+// Size of each part is determined by seeking to its end.
+// Parts are pulled from the sequence lazily, only when data at their offsets is requested.
+// If the parts implement io.Closer, they are closed by MultiSectionReader.Close.
+func MultiReadSeeker[R io.ReadSeeker](parts iter.Seq[R]) *MultiSectionReader {
+	return newMultiSectionReader(func(yield func(sectionPart, error) bool) {
+		for part := range parts {
+			size, err := part.Seek(0, io.SeekEnd)
+			if err != nil {
+				err = fmt.Errorf("seeking to the end of part: %w", err)
+			}
+
+			if !yield(sectionPart{ReaderAt: &readSeekerAt{rs: part}, size: size, origin: part}, err) {
+				return
+			}
+		}
+	})
+}
+
+// MultiSectionReader is a logical concatenation of parts with known sizes.
+// It implements io.Reader, io.Seeker, io.ReaderAt and io.Closer.
+//
+// ReadAt is safe for concurrent use, if parts support concurrent ReadAt calls.
+// Read and Seek share the current offset and must not be called concurrently.
+type MultiSectionReader struct {
+	mu      sync.Mutex
+	next    func() (sectionPart, error, bool)
+	stop    func()
+	parts   []sectionPart
+	ends    []int64 // ends[i] is the end offset of parts[i]
+	drained bool
+	err     error
+
+	offset int64
+}
+
+type sectionPart struct {
+	io.ReaderAt
+	size   int64
+	origin any
+}
+
+func newMultiSectionReader(parts iter.Seq2[sectionPart, error]) *MultiSectionReader {
+	next, stop := iter.Pull2(parts)
+
+	return &MultiSectionReader{
+		next: next,
+		stop: stop,
+	}
+}
+
+// Size returns the total size of all parts, so MultiSectionReader implements SizedReaderAt
+// and can be used as a part of another MultiReaderAt.
+// It pulls all parts from the sequence.
+// If pulling a part fails, Size returns the total size of parts pulled before the failure.
+// The error is reported by the following ReadAt, Read and Seek calls.
+func (msr *MultiSectionReader) Size() int64 {
+	size, _ := msr.load(-1)
+	return size
+}
+
+// ReadAt implements io.ReaderAt.
+func (msr *MultiSectionReader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("itermore.MultiSectionReader.ReadAt: negative offset")
+	}
+
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+
+		part, start, err := msr.partAt(pos)
+		if err != nil {
+			return n, err
+		}
+
+		chunk := p[n:]
+		if rest := start + part.size - pos; rest < int64(len(chunk)) {
+			chunk = chunk[:rest]
+		}
+
+		m, err := part.ReadAt(chunk, pos-start)
+		n += m
+
+		if m == len(chunk) {
+			continue
+		}
+
+		if err == nil || errors.Is(err, io.EOF) {
+			// the part is shorter than its declared size
+			err = io.ErrUnexpectedEOF
+		}
+
+		return n, err
+	}
+
+	return n, nil
+}
+
+// Read implements io.Reader.
+func (msr *MultiSectionReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	n, err := msr.ReadAt(p, msr.offset)
+	msr.offset += int64(n)
+
+	if n > 0 && errors.Is(err, io.EOF) {
+		return n, nil
+	}
+
+	return n, err
+}
+
+// Seek implements io.Seeker.
+// Seeking relative to the end pulls all parts from the sequence.
+func (msr *MultiSectionReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+		// pass
+	case io.SeekCurrent:
+		offset += msr.offset
+	case io.SeekEnd:
+		size, err := msr.load(-1)
+		if err != nil {
+			return msr.offset, err
+		}
+		offset += size
+	default:
+		return msr.offset, errors.New("itermore.MultiSectionReader.Seek: invalid whence")
+	}
+
+	if offset < 0 {
+		return msr.offset, errors.New("itermore.MultiSectionReader.Seek: negative position")
+	}
+
+	msr.offset = offset
+
+	return offset, nil
+}
+
+// Close stops the sequence of parts and closes all pulled parts, which implement io.Closer.
+func (msr *MultiSectionReader) Close() error {
+	msr.mu.Lock()
+	defer msr.mu.Unlock()
+
+	msr.stop()
+	msr.drained = true
+
+	var errs []error
+	for _, part := range msr.parts {
+		if closer, ok := part.origin.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+	msr.parts, msr.ends = nil, nil
+
+	return errors.Join(errs...)
+}
+
+// partAt returns the part, which contains the given offset, and its start offset.
+func (msr *MultiSectionReader) partAt(pos int64) (sectionPart, int64, error) {
+	if _, err := msr.load(pos); err != nil {
+		return sectionPart{}, 0, err
+	}
+
+	msr.mu.Lock()
+	defer msr.mu.Unlock()
+
+	i, _ := slices.BinarySearch(msr.ends, pos+1)
+	if i == len(msr.ends) {
+		return sectionPart{}, 0, io.EOF
+	}
+
+	part := msr.parts[i]
+
+	return part, msr.ends[i] - part.size, nil
+}
+
+// load pulls parts until they cover the given offset or the sequence is drained.
+// Negative offset means loading all parts.
+// It returns the total size of loaded parts.
+func (msr *MultiSectionReader) load(pos int64) (int64, error) {
+	msr.mu.Lock()
+	defer msr.mu.Unlock()
+
+	for msr.err == nil && !msr.drained && (pos < 0 || msr.size() <= pos) {
+		part, err, ok := msr.next()
+		if !ok {
+			msr.drained = true
+			// stop the iterator earlier to help GC
+			msr.stop()
+			break
+		}
+
+		if err != nil {
+			msr.err = err
+			break
+		}
+
+		msr.parts = append(msr.parts, part)
+		msr.ends = append(msr.ends, msr.size()+part.size)
+	}
+
+	return msr.size(), msr.err
+}
+
+func (msr *MultiSectionReader) size() int64 {
+	if len(msr.ends) == 0 {
+		return 0
+	}
+
+	return msr.ends[len(msr.ends)-1]
+}
+
+// readSeekerAt implements io.ReaderAt on top of io.ReadSeeker.
+type readSeekerAt struct {
+	mu sync.Mutex
+	rs io.ReadSeeker
+}
+
+func (rsa *readSeekerAt) ReadAt(p []byte, off int64) (int, error) {
+	rsa.mu.Lock()
+	defer rsa.mu.Unlock()
+
+	if _, err := rsa.rs.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	n, err := io.ReadFull(rsa.rs, p)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		err = io.EOF
+	}
+
+	return n, err
+}
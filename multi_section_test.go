@@ -0,0 +1,231 @@
+package itermore_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/ninedraft/itermore"
+)
+
+func ExampleMultiReaderAt() {
+	parts := itermore.Items(
+		strings.NewReader("hello"),
+		strings.NewReader(", "),
+		strings.NewReader("world"),
+	)
+
+	re := itermore.MultiReaderAt(parts)
+	defer re.Close()
+
+	buf := make([]byte, 6)
+	n, _ := re.ReadAt(buf, 3)
+	fmt.Printf("%q\n", buf[:n])
+	// Output: "lo, wo"
+}
+
+// countedParts returns a sequence of readers and a pointer to number of pulled readers.
+func countedParts(parts ...string) (iter.Seq[*strings.Reader], *int) {
+	pulled := 0
+
+	return func(yield func(*strings.Reader) bool) {
+		for _, part := range parts {
+			pulled++
+			if !yield(strings.NewReader(part)) {
+				return
+			}
+		}
+	}, &pulled
+}
+
+func TestMultiReaderAt(t *testing.T) {
+	t.Parallel()
+
+	parts := []string{"foo", "", "bar", "bazz", "", "q"}
+	content := strings.Join(parts, "")
+
+	t.Run("iotest", func(t *testing.T) {
+		t.Parallel()
+
+		seq, _ := countedParts(parts...)
+		re := itermore.MultiReaderAt(seq)
+		t.Cleanup(func() { _ = re.Close() })
+
+		if err := iotest.TestReader(re, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("lazy", func(t *testing.T) {
+		t.Parallel()
+
+		seq, pulled := countedParts(parts...)
+		re := itermore.MultiReaderAt(seq)
+		t.Cleanup(func() { _ = re.Close() })
+
+		buf := make([]byte, 2)
+		if _, err := re.ReadAt(buf, 2); err != nil {
+			t.Fatal(err)
+		}
+
+		if string(buf) != "ob" {
+			t.Errorf("got %q, want %q", buf, "ob")
+		}
+
+		if *pulled != 3 {
+			t.Errorf("must pull only required parts, pulled %d", *pulled)
+		}
+
+		if size := re.Size(); size != int64(len(content)) {
+			t.Errorf("got size %d, want %d", size, len(content))
+		}
+
+		if *pulled != len(parts) {
+			t.Errorf("Size must pull all parts, pulled %d", *pulled)
+		}
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		t.Parallel()
+
+		first, _ := countedParts(parts[:2]...)
+		second, _ := countedParts(parts[2:]...)
+
+		inner := []*itermore.MultiSectionReader{itermore.MultiReaderAt(first), itermore.MultiReaderAt(second)}
+		for _, re := range inner {
+			t.Cleanup(func() { _ = re.Close() })
+		}
+
+		re := itermore.MultiReaderAt(slices.Values(inner))
+		t.Cleanup(func() { _ = re.Close() })
+
+		got, err := io.ReadAll(io.NewSectionReader(re, 0, re.Size()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(got) != content {
+			t.Errorf("got %q, want %q", got, content)
+		}
+	})
+
+	t.Run("read-at-end", func(t *testing.T) {
+		t.Parallel()
+
+		seq, _ := countedParts(parts...)
+		re := itermore.MultiReaderAt(seq)
+		t.Cleanup(func() { _ = re.Close() })
+
+		buf := make([]byte, 4)
+		n, err := re.ReadAt(buf, int64(len(content)-2))
+		if n != 2 || !errors.Is(err, io.EOF) {
+			t.Errorf("got %d, %v; want 2, EOF", n, err)
+		}
+
+		if got := string(buf[:n]); got != "zq" {
+			t.Errorf("got %q, want %q", got, "zq")
+		}
+	})
+}
+
+type closeRecorder struct {
+	io.ReadSeeker
+	closed *int
+}
+
+func (cr closeRecorder) Close() error {
+	*cr.closed++
+	return nil
+}
+
+func TestMultiReadSeeker(t *testing.T) {
+	t.Parallel()
+
+	parts := []string{"foo", "bar", "", "bazz"}
+	content := strings.Join(parts, "")
+
+	t.Run("iotest", func(t *testing.T) {
+		t.Parallel()
+
+		seq, _ := countedParts(parts...)
+		re := itermore.MultiReadSeeker(seq)
+		t.Cleanup(func() { _ = re.Close() })
+
+		if err := iotest.TestReader(re, []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("close", func(t *testing.T) {
+		t.Parallel()
+
+		closed := 0
+		seq := func(yield func(closeRecorder) bool) {
+			for _, part := range parts {
+				if !yield(closeRecorder{strings.NewReader(part), &closed}) {
+					return
+				}
+			}
+		}
+
+		re := itermore.MultiReadSeeker(seq)
+
+		if _, err := re.Seek(4, io.SeekStart); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := io.ReadAll(io.LimitReader(re, 2))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(got) != "ar" {
+			t.Errorf("got %q, want %q", got, "ar")
+		}
+
+		if err := re.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if closed != 2 {
+			t.Errorf("must close pulled parts, closed %d", closed)
+		}
+	})
+
+	t.Run("seek-error", func(t *testing.T) {
+		t.Parallel()
+
+		errTest := errors.New("test error")
+		seq := itermore.Items[io.ReadSeeker](strings.NewReader("foo"), errSeeker{errTest})
+
+		re := itermore.MultiReadSeeker(seq)
+		t.Cleanup(func() { _ = re.Close() })
+
+		got, err := io.ReadAll(re)
+		if !errors.Is(err, errTest) {
+			t.Fatalf("got %v, want %v", err, errTest)
+		}
+
+		if string(got) != "foo" {
+			t.Errorf("got %q, want %q", got, "foo")
+		}
+
+		if size := re.Size(); size != 3 {
+			t.Errorf("got size %d, want %d", size, 3)
+		}
+
+		if _, err := re.Seek(0, io.SeekEnd); !errors.Is(err, errTest) {
+			t.Errorf("seek: got %v, want %v", err, errTest)
+		}
+	})
+}
+
+type errSeeker struct{ err error }
+
+func (es errSeeker) Read([]byte) (int, error)       { return 0, es.err }
+func (es errSeeker) Seek(int64, int) (int64, error) { return 0, es.err }
@@ -0,0 +1,188 @@
+package itermore
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// PaginateOption configures Paginate.
+type PaginateOption func(*paginateConfig)
+
+type paginateConfig struct {
+	prefetch bool
+	hook     func(PageStats)
+}
+
+// PageStats describes a page fetch.
+type PageStats struct {
+	// Index is the index of the page, starting from 0.
+	Index int
+	// Items is the number of items in the page.
+	Items int
+	// Duration is the time spent in the fetch function.
+	Duration time.Duration
+	// Err is the error returned by the fetch function.
+	Err error
+}
+
+// WithPrefetch makes Paginate fetch the next page in a separate goroutine,
+// while items of the current page are consumed.
+func WithPrefetch() PaginateOption {
+	return func(cfg *paginateConfig) {
+		cfg.prefetch = true
+	}
+}
+
+// WithPageHook sets a function, which is called after each page fetch, for example to collect metrics.
+// With prefetching, the hook is called from a separate goroutine, but never concurrently.
+func WithPageHook(hook func(stats PageStats)) PaginateOption {
+	return func(cfg *paginateConfig) {
+		cfg.hook = hook
+	}
+}
+
+// Paginate creates a sequence, which fetches pages of items using the given function and yields items one by one.
+// It is similar to Next, but for paged sources.
+//
+// The first page is fetched with zero cursor. Each page returns the cursor of the next page,
+// the sequence stops after a page with zero next cursor. Pages may be empty.
+// Pages are fetched lazily, when the previous page is consumed, unless WithPrefetch is used.
+//
+// The sequence is error-aware: if fetching fails or ctx is canceled, it yields the error
+// with a zero value as its last pair.
+func Paginate[E any, C comparable](
+	ctx context.Context,
+	fetch func(ctx context.Context, cursor C) (items []E, next C, err error),
+	opts ...PaginateOption,
+) iter.Seq2[E, error] {
+	cfg := &paginateConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	type page struct {
+		items []E
+		next  C
+		err   error
+	}
+
+	fetchPage := func(ctx context.Context, index int, cursor C) page {
+		if err := ctx.Err(); err != nil {
+			return page{err: err}
+		}
+
+		start := time.Now()
+		items, next, err := fetch(ctx, cursor)
+
+		if cfg.hook != nil {
+			cfg.hook(PageStats{
+				Index:    index,
+				Items:    len(items),
+				Duration: time.Since(start),
+				Err:      err,
+			})
+		}
+
+		return page{items: items, next: next, err: err}
+	}
+
+	return func(yield func(E, error) bool) {
+		var empty E
+		var zero C
+
+		ctx, cancel := context.WithCancel(ctx)
+		// prefetched receives the prefetched page
+		var prefetched chan page
+
+		defer func() {
+			cancel()
+			if prefetched != nil {
+				<-prefetched
+			}
+		}()
+
+		cursor := zero
+		for index := 0; ; index++ {
+			var current page
+			if prefetched != nil {
+				current = <-prefetched
+				prefetched = nil
+			} else {
+				current = fetchPage(ctx, index, cursor)
+			}
+
+			if current.err != nil {
+				yield(empty, current.err)
+				return
+			}
+
+			cursor = current.next
+			more := cursor != zero
+
+			if more && cfg.prefetch {
+				prefetched = make(chan page, 1)
+				go func(result chan<- page, index int, cursor C) {
+					result <- fetchPage(ctx, index, cursor)
+				}(prefetched, index+1, cursor)
+			}
+
+			for _, item := range current.items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if !more {
+				return
+			}
+		}
+	}
+}
+
+// Page is a page of items yielded by Pages.
+type Page[E any] struct {
+	// Index is the index of the page, starting from 0.
+	Index int
+	// Items are items of the page.
+	Items []E
+	// HasNext reports whether there are more pages after this one.
+	HasNext bool
+}
+
+// Pages creates a sequence, which splits the given sequence into pages of the given size.
+// It is an inverse of Paginate.
+// The last page may be shorter. An empty sequence yields no pages.
+// To report HasNext, Pages pulls the first item of the next page before yielding the current one.
+// Each page has its own items slice, which can be retained.
+//
+// It will panic if size is not positive.
+func Pages[E any](seq iter.Seq[E], size int) iter.Seq[Page[E]] {
+	if size <= 0 {
+		panic("itermore.Pages: size must be positive")
+	}
+
+	return func(yield func(Page[E]) bool) {
+		current := Page[E]{}
+
+		for value := range seq {
+			if len(current.Items) == size {
+				current.HasNext = true
+				if !yield(current) {
+					return
+				}
+
+				current = Page[E]{Index: current.Index + 1}
+			}
+
+			if current.Items == nil {
+				current.Items = make([]E, 0, size)
+			}
+			current.Items = append(current.Items, value)
+		}
+
+		if len(current.Items) > 0 {
+			yield(current)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package itermore_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ninedraft/itermore"
+)
+
+// pagedAPI serves items by pages of the given size, cursors are offsets of pages.
+type pagedAPI struct {
+	items   []int
+	size    int
+	failAt  int
+	fetches atomic.Int64
+}
+
+var errPagedAPI = errors.New("paged API error")
+
+func newPagedAPI(n, size int) *pagedAPI {
+	api := &pagedAPI{size: size, failAt: -1}
+	for i := range n {
+		api.items = append(api.items, i)
+	}
+
+	return api
+}
+
+func (api *pagedAPI) fetch(_ context.Context, cursor int) ([]int, int, error) {
+	api.fetches.Add(1)
+
+	if cursor == api.failAt {
+		return nil, 0, errPagedAPI
+	}
+
+	end := min(cursor+api.size, len(api.items))
+	next := end
+	if end == len(api.items) {
+		next = 0
+	}
+
+	return api.items[cursor:end], next, nil
+}
+
+func ExamplePaginate() {
+	fetch := func(_ context.Context, cursor string) ([]string, string, error) {
+		switch cursor {
+		case "":
+			return []string{"a", "b"}, "page-2", nil
+		case "page-2":
+			return []string{"c"}, "", nil
+		default:
+			return nil, "", fmt.Errorf("unexpected cursor %q", cursor)
+		}
+	}
+
+	for item, err := range itermore.Paginate(context.Background(), fetch) {
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(item)
+	}
+	// Output: a
+	// b
+	// c
+}
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	assertBreak2(t, itermore.Paginate(ctx, newPagedAPI(10, 3).fetch))
+
+	for _, prefetch := range []bool{false, true} {
+		var opts []itermore.PaginateOption
+		if prefetch {
+			opts = append(opts, itermore.WithPrefetch())
+		}
+
+		t.Run(fmt.Sprintf("prefetch=%v", prefetch), func(t *testing.T) {
+			t.Parallel()
+
+			t.Run("iter", func(t *testing.T) {
+				t.Parallel()
+
+				api := newPagedAPI(10, 3)
+				var stats []itermore.PageStats
+				hook := itermore.WithPageHook(func(s itermore.PageStats) {
+					stats = append(stats, s)
+				})
+
+				got, err := collectSeqErr(itermore.Paginate(ctx, api.fetch, append(opts, hook)...))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !slices.Equal(got, api.items) {
+					t.Errorf("got %v, want %v", got, api.items)
+				}
+
+				if n := api.fetches.Load(); n != 4 {
+					t.Errorf("got %d fetches, want %d", n, 4)
+				}
+
+				for i, s := range stats {
+					if s.Index != i || s.Items != min(3, 10-3*i) || s.Err != nil {
+						t.Errorf("unexpected stats of page %d: %+v", i, s)
+					}
+				}
+
+				if len(stats) != 4 {
+					t.Errorf("got stats of %d pages, want %d", len(stats), 4)
+				}
+			})
+
+			t.Run("error", func(t *testing.T) {
+				t.Parallel()
+
+				api := newPagedAPI(10, 3)
+				api.failAt = 6
+
+				got, err := collectSeqErr(itermore.Paginate(ctx, api.fetch, opts...))
+				if !errors.Is(err, errPagedAPI) {
+					t.Errorf("got %v, want %v", err, errPagedAPI)
+				}
+
+				if want := api.items[:6]; !slices.Equal(got, want) {
+					t.Errorf("got %v, want %v", got, want)
+				}
+			})
+
+			t.Run("canceled", func(t *testing.T) {
+				t.Parallel()
+
+				ctx, cancel := context.WithCancel(ctx)
+				cancel()
+
+				api := newPagedAPI(10, 3)
+				_, err := collectSeqErr(itermore.Paginate(ctx, api.fetch, opts...))
+				if !errors.Is(err, context.Canceled) {
+					t.Errorf("got %v, want %v", err, context.Canceled)
+				}
+
+				if n := api.fetches.Load(); n != 0 {
+					t.Errorf("got %d fetches, want none", n)
+				}
+			})
+
+			t.Run("empty-pages", func(t *testing.T) {
+				t.Parallel()
+
+				fetch := func(_ context.Context, cursor int) ([]int, int, error) {
+					if cursor < 3 {
+						return nil, cursor + 1, nil
+					}
+					return []int{cursor}, 0, nil
+				}
+
+				got, err := collectSeqErr(itermore.Paginate(ctx, fetch, opts...))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if want := []int{3}; !slices.Equal(got, want) {
+					t.Errorf("got %v, want %v", got, want)
+				}
+			})
+		})
+	}
+
+	t.Run("lazy", func(t *testing.T) {
+		t.Parallel()
+
+		api := newPagedAPI(10, 3)
+		for item := range itermore.Paginate(ctx, api.fetch) {
+			if item == 2 {
+				break
+			}
+		}
+
+		if n := api.fetches.Load(); n != 1 {
+			t.Errorf("got %d fetches, want %d", n, 1)
+		}
+	})
+}
+
+func TestPaginatePrefetchStop(t *testing.T) {
+	defer assertGoroutineLeak(t)()
+
+	api := newPagedAPI(10, 3)
+	for item := range itermore.Paginate(context.Background(), api.fetch, itermore.WithPrefetch()) {
+		if item == 0 {
+			break
+		}
+	}
+
+	// the first page and the prefetched second page
+	if n := api.fetches.Load(); n > 2 {
+		t.Errorf("got %d fetches, want at most %d", n, 2)
+	}
+}
+
+func ExamplePages() {
+	for page := range itermore.Pages(itermore.Items(1, 2, 3, 4, 5), 2) {
+		fmt.Println(page.Index, page.Items, page.HasNext)
+	}
+	// Output: 0 [1 2] true
+	// 1 [3 4] true
+	// 2 [5] false
+}
+
+func TestPages(t *testing.T) {
+	t.Parallel()
+
+	assertBreak(t, itermore.Pages(itermore.Items(1, 2, 3), 2))
+
+	tests := []struct {
+		n, size int
+		want    []itermore.Page[int]
+	}{
+		{n: 0, size: 2, want: nil},
+		{n: 1, size: 2, want: []itermore.Page[int]{{Index: 0, Items: []int{0}}}},
+		{n: 4, size: 2, want: []itermore.Page[int]{
+			{Index: 0, Items: []int{0, 1}, HasNext: true},
+			{Index: 1, Items: []int{2, 3}},
+		}},
+		{n: 5, size: 2, want: []itermore.Page[int]{
+			{Index: 0, Items: []int{0, 1}, HasNext: true},
+			{Index: 1, Items: []int{2, 3}, HasNext: true},
+			{Index: 2, Items: []int{4}},
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%d-by-%d", tc.n, tc.size), func(t *testing.T) {
+			t.Parallel()
+
+			items := make([]int, tc.n)
+			for i := range items {
+				items[i] = i
+			}
+
+			got := slices.Collect(itermore.Pages(itermore.Slice(items), tc.size))
+
+			if !slices.EqualFunc(got, tc.want, func(a, b itermore.Page[int]) bool {
+				return a.Index == b.Index && a.HasNext == b.HasNext && slices.Equal(a.Items, b.Items)
+			}) {
+				t.Errorf("got:  %v", got)
+				t.Errorf("want: %v", tc.want)
+			}
+		})
+	}
+
+	t.Run("lookahead", func(t *testing.T) {
+		t.Parallel()
+
+		pulled := 0
+		seq := func(yield func(int) bool) {
+			for i := range 10 {
+				pulled++
+				if !yield(i) {
+					return
+				}
+			}
+		}
+
+		for page := range itermore.Pages(seq, 3) {
+			if page.Index == 1 {
+				break
+			}
+		}
+
+		// two pages and the first item of the third one
+		if pulled != 7 {
+			t.Errorf("pulled %d items, want %d", pulled, 7)
+		}
+	})
+}
@@ -0,0 +1,54 @@
+package itermore
+
+import "iter"
+
+// Pair is a pair of values.
+// It is useful to store values from iter.Seq2 sequences.
+type Pair[A, B any] struct {
+	A A
+	B B
+}
+
+// PairOf creates a new pair.
+func PairOf[A, B any](a A, b B) Pair[A, B] {
+	return Pair[A, B]{A: a, B: b}
+}
+
+// Values returns both values of the pair.
+func (p Pair[A, B]) Values() (A, B) {
+	return p.A, p.B
+}
+
+// ToPairs creates a sequence that yields pairs from the given sequence as Pair values.
+func ToPairs[A, B any](seq iter.Seq2[A, B]) iter.Seq[Pair[A, B]] {
+	return func(yield func(Pair[A, B]) bool) {
+		for a, b := range seq {
+			if !yield(Pair[A, B]{a, b}) {
+				return
+			}
+		}
+	}
+}
+
+// FromPairs creates a sequence of pairs from the given sequence of Pair values.
+// It is an inverse of ToPairs.
+func FromPairs[A, B any](seq iter.Seq[Pair[A, B]]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		for p := range seq {
+			if !yield(p.A, p.B) {
+				return
+			}
+		}
+	}
+}
+
+// Swap creates a sequence that yields pairs from the given sequence with swapped values.
+func Swap[A, B any](seq iter.Seq2[A, B]) iter.Seq2[B, A] {
+	return func(yield func(B, A) bool) {
+		for a, b := range seq {
+			if !yield(b, a) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package itermore_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/ninedraft/itermore"
+)
+
+func ExampleToPairs() {
+	seq := itermore.Enumerate(itermore.Items("a", "b"))
+
+	pairs := slices.Collect(itermore.ToPairs(seq))
+	fmt.Println(pairs)
+
+	for i, s := range itermore.FromPairs(itermore.Slice(pairs)) {
+		fmt.Println(i, s)
+	}
+	// Output: [{0 a} {1 b}]
+	// 0 a
+	// 1 b
+}
+
+func TestToPairs(t *testing.T) {
+	t.Parallel()
+
+	assertBreak(t, itermore.ToPairs(itermore.One2(1, "a")))
+
+	got := slices.Collect(itermore.ToPairs(itermore.Enumerate(itermore.Items("a", "b"))))
+	want := []itermore.Pair[int, string]{
+		itermore.PairOf(0, "a"),
+		itermore.PairOf(1, "b"),
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("got:  %v", got)
+		t.Errorf("want: %v", want)
+	}
+}
+
+func TestFromPairs(t *testing.T) {
+	t.Parallel()
+
+	input := []itermore.Pair[int, string]{{1, "a"}, {2, "b"}}
+
+	assertBreak2(t, itermore.FromPairs(itermore.Slice(input)))
+
+	got := []pair{}
+	for a, b := range itermore.FromPairs(itermore.Slice(input)) {
+		got = append(got, pair{a, b})
+	}
+
+	want := []pair{{1, "a"}, {2, "b"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("got:  %v", got)
+		t.Errorf("want: %v", want)
+	}
+}
+
+func TestSwap(t *testing.T) {
+	t.Parallel()
+
+	seq := itermore.Zip(itermore.Items("a", "b"), itermore.Items(1, 2))
+
+	assertBreak2(t, itermore.Swap(seq))
+
+	got := []pair{}
+	for a, b := range itermore.Swap(seq) {
+		got = append(got, pair{a, b})
+	}
+
+	want := []pair{{1, "a"}, {2, "b"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("got:  %v", got)
+		t.Errorf("want: %v", want)
+	}
+}
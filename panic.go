@@ -0,0 +1,32 @@
+package itermore
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is a value recovered from a panic in a goroutine started by this package.
+// It is used to propagate panics from background producers to their consumers.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the panicked goroutine.
+	Stack []byte
+}
+
+func newPanicError(value any) *PanicError {
+	return &PanicError{
+		Value: value,
+		Stack: debug.Stack(),
+	}
+}
+
+func (pe *PanicError) Error() string {
+	return fmt.Sprintf("itermore: recovered panic: %v\n\n%s", pe.Value, pe.Stack)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (pe *PanicError) Unwrap() error {
+	err, _ := pe.Value.(error)
+	return err
+}
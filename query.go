@@ -0,0 +1,216 @@
+package itermore
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Query is an in-memory query builder over a sequence.
+// It is inspired by LINQ and SQL: queries are composed from Where, OrderBy, ThenBy, Limit and Offset
+// methods and QuerySelect, QueryDistinct, QueryGroupBy and QueryJoin functions.
+//
+// Query is immutable: each method returns a new query, so a query can be used as a base for several others.
+// Queries are executed lazily, when the result sequence is iterated.
+// Most operations are streaming, only ordering, grouping and the right side of a join
+// buffer values in memory.
+type Query[E any] struct {
+	seq   iter.Seq[E]
+	order []func(a, b E) int
+}
+
+// QueryOf creates a new query over the given sequence.
+func QueryOf[E any](seq iter.Seq[E]) Query[E] {
+	return Query[E]{seq: seq}
+}
+
+// By creates a comparison function, which orders values by the given key in ascending order.
+// It is useful with OrderBy and ThenBy methods.
+func By[E any, K cmp.Ordered](key func(E) K) func(a, b E) int {
+	return func(a, b E) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// Desc reverses the given comparison function.
+func Desc[E any](cmp func(a, b E) int) func(a, b E) int {
+	return func(a, b E) int {
+		return cmp(b, a)
+	}
+}
+
+// Seq returns a sequence of query results.
+// Each iteration executes the query from scratch.
+func (q Query[E]) Seq() iter.Seq[E] {
+	if len(q.order) == 0 {
+		return q.seq
+	}
+
+	seq, order := q.seq, q.order
+	compare := func(a, b E) int {
+		for _, c := range order {
+			if r := c(a, b); r != 0 {
+				return r
+			}
+		}
+		return 0
+	}
+
+	return func(yield func(E) bool) {
+		items := slices.Collect(seq)
+		slices.SortStableFunc(items, compare)
+
+		YieldFrom(yield, Slice(items))
+	}
+}
+
+// Where filters query results with the given predicate.
+// Ordering of the query is kept, so Where can be followed by ThenBy.
+func (q Query[E]) Where(pred func(E) bool) Query[E] {
+	// filtering commutes with stable sorting, so values are filtered before they are sorted
+	return Query[E]{
+		seq:   Filter(q.seq, pred),
+		order: q.order,
+	}
+}
+
+// OrderBy sorts query results with the given comparison function.
+// Sorting is stable, so results of previous ordering are kept for equal values.
+// Use ThenBy to add secondary ordering keys.
+func (q Query[E]) OrderBy(cmp func(a, b E) int) Query[E] {
+	return Query[E]{
+		seq:   q.Seq(),
+		order: []func(a, b E) int{cmp},
+	}
+}
+
+// ThenBy adds a secondary ordering key to the preceding OrderBy.
+// Values, which are equal according to previous keys, are sorted by cmp.
+// Only Where keeps ordering of the query, other methods and functions produce unordered queries.
+// It will panic if the query is not ordered.
+func (q Query[E]) ThenBy(cmp func(a, b E) int) Query[E] {
+	if len(q.order) == 0 {
+		panic("itermore.Query.ThenBy: query is not ordered, use OrderBy first")
+	}
+
+	return Query[E]{
+		seq:   q.seq,
+		order: append(slices.Clip(q.order), cmp),
+	}
+}
+
+// Limit takes first n query results.
+func (q Query[E]) Limit(n int) Query[E] {
+	return QueryOf(TakeN(n, q.Seq()))
+}
+
+// Offset skips first n query results.
+func (q Query[E]) Offset(n int) Query[E] {
+	return QueryOf(SkipN(n, q.Seq()))
+}
+
+// Collect executes the query and returns results as a new slice.
+func (q Query[E]) Collect() []E {
+	return slices.Collect(q.Seq())
+}
+
+// Count executes the query and returns number of results.
+func (q Query[E]) Count() int {
+	return Count(q.Seq())
+}
+
+// QuerySelect maps query results with the given function.
+func QuerySelect[E, R any](q Query[E], fn func(E) R) Query[R] {
+	seq := q.Seq()
+
+	return QueryOf(func(yield func(R) bool) {
+		for value := range seq {
+			if !yield(fn(value)) {
+				return
+			}
+		}
+	})
+}
+
+// QueryDistinct skips repeated query results.
+// Results are yielded in order of their first appearance.
+func QueryDistinct[E comparable](q Query[E]) Query[E] {
+	return QueryDistinctBy(q, func(value E) E { return value })
+}
+
+// QueryDistinctBy skips query results with repeated keys.
+// Only first result for each key is yielded.
+func QueryDistinctBy[E any, K comparable](q Query[E], key func(E) K) Query[E] {
+	seq := q.Seq()
+
+	return QueryOf(func(yield func(E) bool) {
+		seen := map[K]struct{}{}
+		for value := range seq {
+			k := key(value)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+
+			if !yield(value) {
+				return
+			}
+		}
+	})
+}
+
+// QueryGroupBy groups query results by the given key and aggregates each group with agg.
+// Unlike GroupByFn, it groups all results with the same key, not only consecutive ones.
+// Groups are yielded in order of the first appearance of their keys.
+// Values in each group keep their order.
+func QueryGroupBy[E any, K comparable, R any](q Query[E], key func(E) K, agg func(key K, group []E) R) Query[R] {
+	seq := q.Seq()
+
+	return QueryOf(func(yield func(R) bool) {
+		var keys []K
+		groups := map[K][]E{}
+
+		for value := range seq {
+			k := key(value)
+			group, ok := groups[k]
+			if !ok {
+				keys = append(keys, k)
+			}
+			groups[k] = append(group, value)
+		}
+
+		for _, k := range keys {
+			if !yield(agg(k, groups[k])) {
+				return
+			}
+		}
+	})
+}
+
+// QueryJoin performs an inner equi-join of two queries.
+// For each pair of left and right results with equal keys it yields fn(left, right).
+// Results of the right query are buffered in memory, results of the left query are streamed.
+// Output keeps order of the left query, matching right results are yielded in their order.
+func QueryJoin[L, R any, K comparable, O any](
+	left Query[L], right Query[R],
+	leftKey func(L) K, rightKey func(R) K,
+	fn func(L, R) O,
+) Query[O] {
+	leftSeq, rightSeq := left.Seq(), right.Seq()
+
+	return QueryOf(func(yield func(O) bool) {
+		index := map[K][]R{}
+		for r := range rightSeq {
+			k := rightKey(r)
+			index[k] = append(index[k], r)
+		}
+
+		for l := range leftSeq {
+			for _, r := range index[leftKey(l)] {
+				if !yield(fn(l, r)) {
+					return
+				}
+			}
+		}
+	})
+}
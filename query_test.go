@@ -0,0 +1,229 @@
+package itermore_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ninedraft/itermore"
+)
+
+type sale struct {
+	region string
+	item   string
+	amount int
+}
+
+var sales = []sale{
+	{"north", "apple", 10},
+	{"south", "pear", 5},
+	{"north", "pear", 7},
+	{"east", "apple", 3},
+	{"south", "apple", 12},
+	{"north", "plum", 7},
+}
+
+func ExampleQueryGroupBy() {
+	type total struct {
+		region string
+		amount int
+	}
+
+	byRegion := itermore.QueryGroupBy(
+		itermore.QueryOf(itermore.Slice(sales)),
+		func(s sale) string { return s.region },
+		func(region string, group []sale) total {
+			sum := 0
+			for _, s := range group {
+				sum += s.amount
+			}
+			return total{region, sum}
+		},
+	)
+
+	top := byRegion.
+		OrderBy(itermore.Desc(itermore.By(func(t total) int { return t.amount }))).
+		Limit(2)
+
+	for t := range top.Seq() {
+		fmt.Println(t.region, t.amount)
+	}
+	// Output: north 24
+	// south 17
+}
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+
+	base := itermore.QueryOf(itermore.Slice(sales))
+
+	assertBreak(t, base.OrderBy(itermore.By(func(s sale) int { return s.amount })).Seq())
+
+	t.Run("where", func(t *testing.T) {
+		t.Parallel()
+
+		got := base.Where(func(s sale) bool { return s.item == "apple" }).Count()
+		if got != 3 {
+			t.Errorf("got %d, want %d", got, 3)
+		}
+	})
+
+	t.Run("order-then", func(t *testing.T) {
+		t.Parallel()
+
+		got := base.
+			OrderBy(itermore.By(func(s sale) string { return s.region })).
+			ThenBy(itermore.Desc(itermore.By(func(s sale) int { return s.amount }))).
+			Collect()
+
+		want := []sale{
+			{"east", "apple", 3},
+			{"north", "apple", 10},
+			{"north", "pear", 7},
+			{"north", "plum", 7},
+			{"south", "apple", 12},
+			{"south", "pear", 5},
+		}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("order-where-then", func(t *testing.T) {
+		t.Parallel()
+
+		got := base.
+			OrderBy(itermore.By(func(s sale) string { return s.region })).
+			Where(func(s sale) bool { return s.item != "plum" }).
+			ThenBy(itermore.By(func(s sale) int { return s.amount })).
+			Collect()
+
+		want := []sale{
+			{"east", "apple", 3},
+			{"north", "pear", 7},
+			{"north", "apple", 10},
+			{"south", "pear", 5},
+			{"south", "apple", 12},
+		}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("then-unordered", func(t *testing.T) {
+		t.Parallel()
+
+		defer func() {
+			if recover() == nil {
+				t.Error("ThenBy must panic on unordered query")
+			}
+		}()
+
+		base.
+			OrderBy(itermore.By(func(s sale) int { return s.amount })).
+			Limit(3).
+			ThenBy(itermore.By(func(s sale) string { return s.item }))
+	})
+
+	t.Run("order-stable", func(t *testing.T) {
+		t.Parallel()
+
+		got := base.OrderBy(itermore.By(func(s sale) int { return s.amount })).Collect()
+
+		// equal amounts keep source order
+		want := []sale{
+			{"east", "apple", 3},
+			{"south", "pear", 5},
+			{"north", "pear", 7},
+			{"north", "plum", 7},
+			{"north", "apple", 10},
+			{"south", "apple", 12},
+		}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("limit-offset", func(t *testing.T) {
+		t.Parallel()
+
+		amounts := itermore.QuerySelect(base, func(s sale) int { return s.amount })
+		got := amounts.OrderBy(itermore.By(func(x int) int { return x })).Offset(1).Limit(3).Collect()
+
+		want := []int{5, 7, 7}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("distinct", func(t *testing.T) {
+		t.Parallel()
+
+		items := itermore.QuerySelect(base, func(s sale) string { return s.item })
+		got := itermore.QueryDistinct(items).Collect()
+
+		want := []string{"apple", "pear", "plum"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("join", func(t *testing.T) {
+		t.Parallel()
+
+		type region struct {
+			name    string
+			manager string
+		}
+
+		regions := itermore.QueryOf(itermore.Items(
+			region{"north", "alice"},
+			region{"south", "bob"},
+		))
+
+		joined := itermore.QueryJoin(
+			base.Where(func(s sale) bool { return s.item == "apple" }),
+			regions,
+			func(s sale) string { return s.region },
+			func(r region) string { return r.name },
+			func(s sale, r region) string { return r.manager + ":" + s.item },
+		)
+
+		got := strings.Join(joined.Collect(), ",")
+		want := "alice:apple,bob:apple"
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("immutable", func(t *testing.T) {
+		t.Parallel()
+
+		ordered := base.OrderBy(itermore.By(func(s sale) string { return s.region }))
+		byItem := ordered.ThenBy(itermore.By(func(s sale) string { return s.item }))
+		byAmount := ordered.ThenBy(itermore.By(func(s sale) int { return s.amount }))
+
+		gotItem := itermore.QuerySelect(byItem, func(s sale) string { return s.item }).Limit(4).Collect()
+		wantItem := []string{"apple", "apple", "pear", "plum"}
+		if !slices.Equal(gotItem, wantItem) {
+			t.Errorf("got:  %v", gotItem)
+			t.Errorf("want: %v", wantItem)
+		}
+
+		gotAmount := itermore.QuerySelect(byAmount, func(s sale) int { return s.amount }).Limit(4).Collect()
+		wantAmount := []int{3, 7, 7, 10}
+		if !slices.Equal(gotAmount, wantAmount) {
+			t.Errorf("got:  %v", gotAmount)
+			t.Errorf("want: %v", wantAmount)
+		}
+	})
+}
@@ -40,6 +40,23 @@ func Loop[E any](items []E) iter.Seq[E] {
 	}
 }
 
+// Loop2 forever yields pairs from the given slice in the order they appear in the slice.
+// After the last pair is yielded, the sequence will start from the beginning.
+// It is similar to Loop, but works with sequences of pairs.
+func Loop2[A, B any](items []Pair[A, B]) iter.Seq2[A, B] {
+	if len(items) == 0 {
+		return None2[A, B]
+	}
+
+	return func(yield func(A, B) bool) {
+		for i := 0; ; i = (i + 1) % len(items) {
+			if !yield(items[i].A, items[i].B) {
+				return
+			}
+		}
+	}
+}
+
 // Collect writes values from provided sequence to the given slice.
 // If dst slice is nil, Collect will create a new slice.
 // It can return a new slice or the same slice that was passed as dst following the same rules as append function.
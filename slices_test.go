@@ -163,3 +163,37 @@ func TestFlattenSlices(t *testing.T) {
 		}
 	})
 }
+
+func TestLoop2(t *testing.T) {
+	t.Parallel()
+
+	items := []itermore.Pair[int, string]{{1, "a"}, {2, "b"}}
+
+	assertBreak2(t, itermore.Loop2(items))
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		got := []pair{}
+		for a, b := range itermore.Loop2(items) {
+			got = append(got, pair{a, b})
+			if len(got) >= 5 {
+				break
+			}
+		}
+
+		want := []pair{{1, "a"}, {2, "b"}, {1, "a"}, {2, "b"}, {1, "a"}}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		for a, b := range itermore.Loop2[int, string](nil) {
+			t.Fatalf("must not iterate over empty seq, got: %v, %v", a, b)
+		}
+	})
+}
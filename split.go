@@ -0,0 +1,209 @@
+package itermore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"math"
+)
+
+// SplitReader creates a sequence of readers, which yield sections of the given reader separated by delim.
+// Delimiters are consumed and not included into sections. Input ending with delim doesn't produce an empty trailing section.
+// It is an inverse of CollectJoinReaders and MultiReader.
+//
+// Sections are read lazily from the underlying reader and are never buffered as a whole.
+// Each section is valid until the next iteration: unread rest of the section is discarded
+// when the next section is requested.
+// Read errors are returned by Read method of the current section. If discarding of a section fails,
+// the sequence yields a last reader, which returns the error.
+//
+// It will panic if delim is empty.
+func SplitReader(re io.Reader, delim []byte) iter.Seq[io.Reader] {
+	if len(delim) == 0 {
+		panic("itermore.SplitReader: empty delimiter")
+	}
+
+	delim = bytes.Clone(delim)
+
+	return func(yield func(io.Reader) bool) {
+		br := bufio.NewReaderSize(re, max(defaultBufferSize, 2*len(delim)))
+
+		splitSections(br, yield, func() (section, error) {
+			return &delimSection{br: br, delim: delim}, nil
+		})
+	}
+}
+
+// SectionReaders creates a sequence of readers, which yield sections of the given reader of the given size.
+// The last section may be shorter.
+// See SplitReader for details on sections lifetime and error handling.
+//
+// It will panic if size is not positive.
+func SectionReaders(re io.Reader, size int64) iter.Seq[io.Reader] {
+	if size <= 0 {
+		panic("itermore.SectionReaders: size must be positive")
+	}
+
+	return func(yield func(io.Reader) bool) {
+		br := bufio.NewReader(re)
+
+		splitSections(br, yield, func() (section, error) {
+			return &limitedSection{LimitedReader: io.LimitedReader{R: br, N: size}}, nil
+		})
+	}
+}
+
+// LengthPrefixedReaders creates a sequence of readers, which yield sections of the given reader.
+// Each section is prefixed with its length, encoded as unsigned varint (see binary.AppendUvarint).
+// If the underlying reader ends before the section does, reading the section returns io.ErrUnexpectedEOF.
+// See SplitReader for details on sections lifetime and error handling.
+func LengthPrefixedReaders(re io.Reader) iter.Seq[io.Reader] {
+	return func(yield func(io.Reader) bool) {
+		br := bufio.NewReader(re)
+
+		splitSections(br, yield, func() (section, error) {
+			size, err := binary.ReadUvarint(br)
+			if err != nil {
+				return nil, fmt.Errorf("reading section length: %w", noEOF(err))
+			}
+
+			if size > math.MaxInt64 {
+				return nil, fmt.Errorf("section length %d is too large", size)
+			}
+
+			return &limitedSection{
+				LimitedReader: io.LimitedReader{R: br, N: int64(size)},
+				exact:         true,
+			}, nil
+		})
+	}
+}
+
+type section interface {
+	io.Reader
+	// last reports whether there are no more sections after this one.
+	last() bool
+}
+
+func splitSections(br *bufio.Reader, yield func(io.Reader) bool, next func() (section, error)) {
+	for {
+		_, err := br.Peek(1)
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			yield(errorReader{err})
+			return
+		}
+
+		sec, err := next()
+		if err != nil {
+			yield(errorReader{err})
+			return
+		}
+
+		if !yield(sec) {
+			return
+		}
+
+		// discard unread rest of the section
+		if _, err := io.Copy(io.Discard, sec); err != nil {
+			yield(errorReader{err})
+			return
+		}
+
+		if sec.last() {
+			return
+		}
+	}
+}
+
+type delimSection struct {
+	br    *bufio.Reader
+	delim []byte
+	done  bool
+	isEOF bool
+}
+
+func (ds *delimSection) last() bool { return ds.isEOF }
+
+func (ds *delimSection) Read(p []byte) (int, error) {
+	if ds.done {
+		return 0, io.EOF
+	}
+
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	buf, err := ds.br.Peek(max(ds.br.Buffered(), len(ds.delim)))
+
+	if i := bytes.Index(buf, ds.delim); i >= 0 {
+		if i == 0 {
+			_, _ = ds.br.Discard(len(ds.delim))
+			ds.done = true
+			return 0, io.EOF
+		}
+
+		return ds.consume(p, buf[:i]), nil
+	}
+
+	// delimiter can start in the tail of the buffer
+	safe := len(buf) - len(ds.delim) + 1
+
+	switch {
+	case errors.Is(err, io.EOF):
+		safe = len(buf)
+		if safe == 0 {
+			ds.done, ds.isEOF = true, true
+			return 0, io.EOF
+		}
+	case err != nil && safe <= 0:
+		return 0, err
+	}
+
+	return ds.consume(p, buf[:max(safe, 0)]), nil
+}
+
+func (ds *delimSection) consume(p, buf []byte) int {
+	n := copy(p, buf)
+	_, _ = ds.br.Discard(n)
+
+	return n
+}
+
+type limitedSection struct {
+	io.LimitedReader
+	// exact requires section to be read fully.
+	exact bool
+}
+
+func (ls *limitedSection) last() bool { return false }
+
+func (ls *limitedSection) Read(p []byte) (int, error) {
+	n, err := ls.LimitedReader.Read(p)
+	if ls.exact && ls.N > 0 && errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return n, err
+}
+
+type errorReader struct{ err error }
+
+func (er errorReader) Read([]byte) (int, error) {
+	return 0, er.err
+}
+
+// noEOF converts io.EOF to io.ErrUnexpectedEOF.
+func noEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
@@ -0,0 +1,220 @@
+package itermore_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/ninedraft/itermore"
+)
+
+func ExampleSplitReader() {
+	input := strings.NewReader("first--second--third")
+
+	for section := range itermore.SplitReader(input, []byte("--")) {
+		data, err := io.ReadAll(section)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(string(data))
+	}
+	// Output: first
+	// second
+	// third
+}
+
+// readSections reads all sections into strings.
+func readSections(seq iter.Seq[io.Reader]) ([]string, error) {
+	got := []string{}
+	for section := range seq {
+		data, err := io.ReadAll(section)
+		if err != nil {
+			return got, err
+		}
+		got = append(got, string(data))
+	}
+
+	return got, nil
+}
+
+func TestSplitReader(t *testing.T) {
+	t.Parallel()
+
+	assertBreak(t, itermore.SplitReader(strings.NewReader("a,b"), []byte(",")))
+
+	tc := func(name, input, delim string, want []string) {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			readers := map[string]io.Reader{
+				"plain":    strings.NewReader(input),
+				"one-byte": iotest.OneByteReader(strings.NewReader(input)),
+				"half":     iotest.HalfReader(strings.NewReader(input)),
+			}
+
+			for kind, re := range readers {
+				got, err := readSections(itermore.SplitReader(re, []byte(delim)))
+				if err != nil {
+					t.Fatalf("%s: %v", kind, err)
+				}
+
+				if !slices.Equal(got, want) {
+					t.Errorf("%s got:  %q", kind, got)
+					t.Errorf("%s want: %q", kind, want)
+				}
+			}
+		})
+	}
+
+	tc("single", "a,b,c", ",", []string{"a", "b", "c"})
+	tc("multi", "alpha<>>beta<><>gamma<>", "<>", []string{"alpha", ">beta", "", "gamma"})
+	tc("no-delim", "alpha", "<>", []string{"alpha"})
+	tc("only-delim", "<>", "<>", []string{""})
+	tc("empty", "", "<>", []string{})
+	tc("partial-delim", "a<b<", "<>", []string{"a<b<"})
+
+	t.Run("skip-unread", func(t *testing.T) {
+		t.Parallel()
+
+		input := strings.NewReader(strings.Repeat("x", 100_000) + "|tail")
+
+		got := []string{}
+		for section := range itermore.SplitReader(input, []byte("|")) {
+			buf := make([]byte, 3)
+			n, _ := io.ReadFull(section, buf)
+			got = append(got, string(buf[:n]))
+		}
+
+		want := []string{"xxx", "tai"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+	})
+
+	t.Run("rejoin", func(t *testing.T) {
+		t.Parallel()
+
+		input := "a\nbb\nccc"
+		sections := itermore.SplitReader(strings.NewReader(input), []byte("\n"))
+
+		buf := &strings.Builder{}
+		if _, err := itermore.CollectJoinReaders(buf, sections, []byte("\n")); err != nil {
+			t.Fatal(err)
+		}
+
+		if buf.String() != input {
+			t.Errorf("got %q, want %q", buf.String(), input)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		errTest := errors.New("test error")
+		input := io.MultiReader(strings.NewReader("a,b"), &errReader{err: errTest})
+
+		got, err := readSections(itermore.SplitReader(input, []byte(",")))
+		if !errors.Is(err, errTest) {
+			t.Fatalf("got %v, want %v", err, errTest)
+		}
+
+		if want := []string{"a"}; !slices.Equal(got, want) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+	})
+}
+
+func TestSectionReaders(t *testing.T) {
+	t.Parallel()
+
+	assertBreak(t, itermore.SectionReaders(strings.NewReader("abc"), 2))
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := readSections(itermore.SectionReaders(strings.NewReader("abcdefg"), 3))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"abc", "def", "g"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+	})
+
+	t.Run("skip-unread", func(t *testing.T) {
+		t.Parallel()
+
+		got := []string{}
+		for section := range itermore.SectionReaders(strings.NewReader("abcdef"), 3) {
+			buf := make([]byte, 1)
+			n, _ := section.Read(buf)
+			got = append(got, string(buf[:n]))
+		}
+
+		want := []string{"a", "d"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+	})
+}
+
+func TestLengthPrefixedReaders(t *testing.T) {
+	t.Parallel()
+
+	encode := func(sections ...string) string {
+		var buf []byte
+		for _, s := range sections {
+			buf = binary.AppendUvarint(buf, uint64(len(s)))
+			buf = append(buf, s...)
+		}
+		return string(buf)
+	}
+
+	assertBreak(t, itermore.LengthPrefixedReaders(strings.NewReader(encode("a", "b"))))
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		want := []string{"a", "", strings.Repeat("b", 300)}
+		input := strings.NewReader(encode(want...))
+
+		got, err := readSections(itermore.LengthPrefixedReaders(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		t.Parallel()
+
+		input := encode("abc", "defg")
+		input = input[:len(input)-1]
+
+		got, err := readSections(itermore.LengthPrefixedReaders(strings.NewReader(input)))
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Fatalf("got %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+
+		if want := []string{"abc"}; !slices.Equal(got, want) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+	})
+}
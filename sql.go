@@ -0,0 +1,194 @@
+package itermore
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"iter"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Queryer executes queries, which return rows.
+// It is implemented by *sql.DB, *sql.Tx and *sql.Conn.
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Rows creates a sequence, which executes the query and yields scanned rows.
+// The query is executed each time the sequence is iterated, rows are closed when the iteration stops.
+//
+// Struct types are scanned column by column: columns are mapped to exported struct fields
+// by `db:"name"` tags or by field names, ignoring case. Fields tagged with `db:"-"` are ignored,
+// as well as columns without matching fields.
+// Fields of embedded structs are promoted, nil embedded pointers are allocated for each row.
+// Other types, types implementing sql.Scanner and time.Time are scanned from a single column.
+//
+// The sequence is error-aware: if the query, scanning or reading of rows fails,
+// it yields the error with a zero value as its last pair.
+func Rows[T any](ctx context.Context, db Queryer, query string, args ...any) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		rows, err := db.QueryContext(ctx, query, args...)
+		if err != nil {
+			var empty T
+			yield(empty, err)
+			return
+		}
+		defer func() { _ = rows.Close() }()
+
+		yieldRows(rows, yield)
+	}
+}
+
+// RowsKeyset creates a sequence, which fetches rows page by page using keyset pagination and yields scanned rows.
+// See Rows for details on scanning and error handling.
+//
+// The page function builds a query for the page of the given size, which contains rows after the given key.
+// The key is not set for the first page. The key function extracts the key from the last row of the previous page.
+// Pages are fetched lazily, when the previous page is consumed.
+// The sequence stops after a page, which is shorter than limit.
+//
+// It will panic if limit is not positive.
+func RowsKeyset[T, K any](
+	ctx context.Context,
+	db Queryer,
+	limit int,
+	page func(after Option[K], limit int) (query string, args []any),
+	key func(row T) K,
+) iter.Seq2[T, error] {
+	if limit <= 0 {
+		panic("itermore.RowsKeyset: limit must be positive")
+	}
+
+	return func(yield func(T, error) bool) {
+		var after Option[K]
+
+		for {
+			query, args := page(after, limit)
+
+			n := 0
+			var last T
+			for row, err := range Rows[T](ctx, db, query, args...) {
+				if err != nil {
+					yield(row, err)
+					return
+				}
+
+				n++
+				last = row
+
+				if !yield(row, nil) {
+					return
+				}
+			}
+
+			if n < limit {
+				return
+			}
+
+			after = Some(key(last))
+		}
+	}
+}
+
+func yieldRows[T any](rows *sql.Rows, yield func(T, error) bool) {
+	var empty T
+
+	columns, err := rows.Columns()
+	if err != nil {
+		yield(empty, err)
+		return
+	}
+
+	var value T
+	target := reflect.ValueOf(&value).Elem()
+
+	indexes, err := scanFields(target.Type(), columns)
+	if err != nil {
+		yield(empty, err)
+		return
+	}
+
+	dest := make([]any, len(columns))
+	for rows.Next() {
+		target.SetZero()
+		// zeroing resets embedded pointers, so targets are collected for each row
+		scanTargets(dest, target, indexes)
+
+		if err := rows.Scan(dest...); err != nil {
+			yield(empty, err)
+			return
+		}
+
+		if !yield(value, nil) {
+			return
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		yield(empty, err)
+	}
+}
+
+var (
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	timeType    = reflect.TypeFor[time.Time]()
+)
+
+// scanFields returns indexes of fields of typ, which correspond to the given columns.
+// Columns without matching fields have nil indexes.
+// It returns nil indexes if typ is scanned as a single value.
+func scanFields(typ reflect.Type, columns []string) ([][]int, error) {
+	if typ.Kind() != reflect.Struct || typ == timeType || reflect.PointerTo(typ).Implements(scannerType) {
+		if len(columns) != 1 {
+			return nil, fmt.Errorf("itermore: scanning %d columns into %v, expected 1 column", len(columns), typ)
+		}
+
+		return nil, nil
+	}
+
+	fields := map[string][]int{}
+	for _, field := range reflect.VisibleFields(typ) {
+		if !field.IsExported() || field.Anonymous || !isFieldReachable(typ, field.Index) {
+			continue
+		}
+
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("db"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+
+		fields[strings.ToLower(name)] = field.Index
+	}
+
+	indexes := make([][]int, len(columns))
+	for i, column := range columns {
+		indexes[i] = fields[strings.ToLower(column)]
+	}
+
+	return indexes, nil
+}
+
+// scanTargets fills dest with pointers to fields of target with the given indexes.
+// Nil embedded pointers of target are allocated.
+func scanTargets(dest []any, target reflect.Value, indexes [][]int) {
+	if indexes == nil {
+		dest[0] = target.Addr().Interface()
+		return
+	}
+
+	for i, index := range indexes {
+		switch {
+		case index != nil:
+			dest[i] = fieldByIndexAlloc(target, index).Addr().Interface()
+		case dest[i] == nil:
+			dest[i] = new(any)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+package itermore_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/ninedraft/itermore"
+)
+
+// fakeConnector is a database/sql connector, which serves queries with the handler.
+type fakeConnector struct {
+	handler func(query string, args []driver.NamedValue) (*fakeRows, error)
+	// closed is the number of closed rows.
+	closed int
+}
+
+func openFakeDB(t *testing.T, handler func(query string, args []driver.NamedValue) (*fakeRows, error)) (*sql.DB, *fakeConnector) {
+	t.Helper()
+
+	connector := &fakeConnector{handler: handler}
+	db := sql.OpenDB(connector)
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db, connector
+}
+
+func (fc *fakeConnector) Connect(context.Context) (driver.Conn, error) { return &fakeConn{fc}, nil }
+func (fc *fakeConnector) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, errors.New("not supported") }
+
+type fakeConn struct{ connector *fakeConnector }
+
+func (fc *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (fc *fakeConn) Close() error                        { return nil }
+func (fc *fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (fc *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := fc.connector.handler(query, args)
+	if err != nil {
+		return nil, err
+	}
+	rows.connector = fc.connector
+
+	return rows, nil
+}
+
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+	// err is returned after all values are read.
+	err       error
+	connector *fakeConnector
+}
+
+func (fr *fakeRows) Columns() []string { return fr.columns }
+
+func (fr *fakeRows) Close() error {
+	fr.connector.closed++
+	return nil
+}
+
+func (fr *fakeRows) Next(dest []driver.Value) error {
+	if len(fr.values) == 0 {
+		if fr.err != nil {
+			return fr.err
+		}
+		return io.EOF
+	}
+
+	copy(dest, fr.values[0])
+	fr.values = fr.values[1:]
+
+	return nil
+}
+
+type user struct {
+	ID      int64  `db:"id"`
+	Name    string `db:"user_name"`
+	Email   sql.NullString
+	Ignored string `db:"-"`
+}
+
+type UserBase struct {
+	ID int64 `db:"id"`
+}
+
+type userEmbed struct {
+	*UserBase
+	Name string `db:"user_name"`
+}
+
+func usersHandler(count int) func(string, []driver.NamedValue) (*fakeRows, error) {
+	return func(query string, args []driver.NamedValue) (*fakeRows, error) {
+		after, limit := int64(0), int64(count)
+		if len(args) == 2 {
+			after, limit = args[0].Value.(int64), args[1].Value.(int64)
+		}
+
+		rows := &fakeRows{columns: []string{"id", "USER_NAME", "email", "ignored", "extra"}}
+		for id := after + 1; id <= int64(count) && len(rows.values) < int(limit); id++ {
+			email := any(nil)
+			if id%2 == 0 {
+				email = fmt.Sprintf("user%d@example.com", id)
+			}
+			rows.values = append(rows.values, []driver.Value{id, fmt.Sprintf("user%d", id), email, "x", "y"})
+		}
+
+		return rows, nil
+	}
+}
+
+func ExampleRows() {
+	db := sql.OpenDB(&fakeConnector{handler: usersHandler(3)})
+	defer db.Close()
+
+	for u, err := range itermore.Rows[user](context.Background(), db, "SELECT id, user_name, email FROM users") {
+		if err != nil {
+			panic(err)
+		}
+		fmt.Printf("%d %s %q\n", u.ID, u.Name, u.Email.String)
+	}
+	// Output: 1 user1 ""
+	// 2 user2 "user2@example.com"
+	// 3 user3 ""
+}
+
+func TestRows(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("struct", func(t *testing.T) {
+		t.Parallel()
+
+		db, connector := openFakeDB(t, usersHandler(3))
+
+		got, err := collectSeqErr(itermore.Rows[user](ctx, db, "users"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []user{
+			{ID: 1, Name: "user1"},
+			{ID: 2, Name: "user2", Email: sql.NullString{String: "user2@example.com", Valid: true}},
+			{ID: 3, Name: "user3"},
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+
+		if connector.closed != 1 {
+			t.Errorf("rows are closed %d times, want %d", connector.closed, 1)
+		}
+	})
+
+	t.Run("embedded-pointer", func(t *testing.T) {
+		t.Parallel()
+
+		db, _ := openFakeDB(t, usersHandler(2))
+
+		got, err := collectSeqErr(itermore.Rows[userEmbed](ctx, db, "users"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(got) != 2 {
+			t.Fatalf("got %d rows, want 2", len(got))
+		}
+
+		if got[0].UserBase == nil || got[1].UserBase == nil || got[0].UserBase == got[1].UserBase {
+			t.Fatalf("each row must have its own embedded value: %+v", got)
+		}
+
+		for i, row := range got {
+			if want := int64(i + 1); row.ID != want {
+				t.Errorf("row %d: got id %d, want %d", i, row.ID, want)
+			}
+
+			if want := fmt.Sprintf("user%d", i+1); row.Name != want {
+				t.Errorf("row %d: got name %q, want %q", i, row.Name, want)
+			}
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		t.Parallel()
+
+		db, connector := openFakeDB(t, usersHandler(10))
+
+		for range itermore.Rows[user](ctx, db, "users") {
+			break
+		}
+
+		if connector.closed != 1 {
+			t.Errorf("rows are closed %d times, want %d", connector.closed, 1)
+		}
+	})
+
+	t.Run("scalar", func(t *testing.T) {
+		t.Parallel()
+
+		db, _ := openFakeDB(t, func(string, []driver.NamedValue) (*fakeRows, error) {
+			return &fakeRows{
+				columns: []string{"name"},
+				values:  [][]driver.Value{{"a"}, {"b"}},
+			}, nil
+		})
+
+		got, err := collectSeqErr(itermore.Rows[string](ctx, db, "names"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := []string{"a", "b"}; !slices.Equal(got, want) {
+			t.Errorf("got %q, want %q", got, want)
+		}
+
+		_, err = collectSeqErr(itermore.Rows[int](ctx, db, "names"))
+		if err == nil {
+			t.Error("expected scan error")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		errTest := errors.New("test error")
+
+		db, _ := openFakeDB(t, func(query string, _ []driver.NamedValue) (*fakeRows, error) {
+			if query == "fail" {
+				return nil, errTest
+			}
+
+			return &fakeRows{
+				columns: []string{"id", "name"},
+				values:  [][]driver.Value{{int64(1), "a"}},
+				err:     errTest,
+			}, nil
+		})
+
+		_, err := collectSeqErr(itermore.Rows[user](ctx, db, "fail"))
+		if !errors.Is(err, errTest) {
+			t.Errorf("query: got %v, want %v", err, errTest)
+		}
+
+		got, err := collectSeqErr(itermore.Rows[user](ctx, db, "rows"))
+		if !errors.Is(err, errTest) {
+			t.Errorf("rows: got %v, want %v", err, errTest)
+		}
+		if len(got) != 1 {
+			t.Errorf("rows: got %v, want a single row", got)
+		}
+
+		_, err = collectSeqErr(itermore.Rows[string](ctx, db, "rows"))
+		if err == nil {
+			t.Error("expected error for multiple columns")
+		}
+	})
+}
+
+func TestRowsKeyset(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	page := func(after itermore.Option[int64], limit int) (string, []any) {
+		return "users page", []any{after.Or(0), limit}
+	}
+	key := func(u user) int64 { return u.ID }
+
+	tests := []struct {
+		count, limit int
+		wantQueries  int
+	}{
+		{count: 7, limit: 3, wantQueries: 3},
+		{count: 6, limit: 3, wantQueries: 3},
+		{count: 2, limit: 3, wantQueries: 1},
+		{count: 0, limit: 3, wantQueries: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%d-by-%d", tc.count, tc.limit), func(t *testing.T) {
+			t.Parallel()
+
+			db, connector := openFakeDB(t, usersHandler(tc.count))
+
+			ids := []int64{}
+			for u, err := range itermore.RowsKeyset(ctx, db, tc.limit, page, key) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				ids = append(ids, u.ID)
+			}
+
+			want := []int64{}
+			for id := range int64(tc.count) {
+				want = append(want, id+1)
+			}
+			if !slices.Equal(ids, want) {
+				t.Errorf("got ids %v, want %v", ids, want)
+			}
+
+			if connector.closed != tc.wantQueries {
+				t.Errorf("got %d queries, want %d", connector.closed, tc.wantQueries)
+			}
+		})
+	}
+
+	t.Run("lazy", func(t *testing.T) {
+		t.Parallel()
+
+		db, connector := openFakeDB(t, usersHandler(10))
+
+		for u := range itermore.RowsKeyset(ctx, db, 3, page, key) {
+			if u.ID == 3 {
+				break
+			}
+		}
+
+		if connector.closed != 1 {
+			t.Errorf("got %d queries, want %d", connector.closed, 1)
+		}
+	})
+}
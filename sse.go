@@ -0,0 +1,178 @@
+package itermore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is a server-sent event.
+// See https://html.spec.whatwg.org/multipage/server-sent-events.html for details.
+type Event struct {
+	// ID is the event ID, it must not contain line breaks.
+	ID string
+	// Event is the event type, it must not contain line breaks.
+	Event string
+	// Data is the event payload, it can contain line breaks.
+	Data string
+	// Retry is the reconnection time, it is written if positive.
+	Retry time.Duration
+}
+
+// WriteSSE writes events from the sequence returned by events to the response as a server-sent event stream.
+// It sets Content-Type and Cache-Control headers, if they are not set yet, and flushes the response after each event.
+// Events with empty Data are not dispatched by browsers, but can be used to set the last event ID or the reconnection time.
+//
+// events is called once with a context, which is canceled, when ctx is canceled or WriteSSE returns.
+// The sequence must end, when the context is done, e.g. it can be built with ChanCtx or TickCtx,
+// so WriteSSE returns promptly, when the client disconnects, even if the sequence is waiting for the next event.
+// WriteSSE doesn't start any goroutines, so nothing outlives the handler.
+//
+// WriteSSE stops, when ctx is canceled, typically when the client disconnects and the request context is done.
+// It returns ctx error in this case.
+// It returns an error if writing or flushing fails, or an event is malformed.
+func WriteSSE(ctx context.Context, w http.ResponseWriter, events func(context.Context) iter.Seq[Event]) error {
+	header := w.Header()
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "text/event-stream")
+	}
+	if header.Get("Cache-Control") == "" {
+		header.Set("Cache-Control", "no-cache")
+	}
+
+	rc := http.NewResponseController(w)
+
+	// send headers before the first event
+	if err := rc.Flush(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	buf := &bytes.Buffer{}
+	for event := range events(ctx) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		buf.Reset()
+		if err := encodeEvent(buf, event); err != nil {
+			return err
+		}
+
+		if _, err := w.Write(buf.Bytes()); err != nil {
+			return err
+		}
+
+		if err := rc.Flush(); err != nil {
+			return err
+		}
+	}
+
+	// the sequence may end early because of cancellation
+	return ctx.Err()
+}
+
+func encodeEvent(buf *bytes.Buffer, event Event) error {
+	if strings.ContainsAny(event.ID, "\r\n") || strings.ContainsAny(event.Event, "\r\n") {
+		return errors.New("itermore: event ID and type must not contain line breaks")
+	}
+
+	if event.ID != "" {
+		buf.WriteString("id: " + event.ID + "\n")
+	}
+
+	if event.Event != "" {
+		buf.WriteString("event: " + event.Event + "\n")
+	}
+
+	if event.Retry > 0 {
+		buf.WriteString("retry: " + strconv.FormatInt(event.Retry.Milliseconds(), 10) + "\n")
+	}
+
+	if event.Data != "" {
+		data := strings.NewReplacer("\r\n", "\n", "\r", "\n").Replace(event.Data)
+		for line := range strings.SplitSeq(data, "\n") {
+			buf.WriteString("data: " + line + "\n")
+		}
+	}
+
+	buf.WriteByte('\n')
+
+	return nil
+}
+
+// ReadSSE creates a sequence, which parses server-sent events from the given stream.
+// Comments and unknown fields are ignored, events are yielded, when any field is set.
+// Unlike browsers, ReadSSE doesn't keep ID of the previous event: ID is empty, if the event has no id field.
+// Incomplete event at the end of the stream is discarded.
+//
+// The sequence is error-aware: if reading fails, it yields the error with a zero event as its last pair.
+// Options configure the maximum line length.
+func ReadSSE(re io.Reader, opts ...ScanOption) iter.Seq2[Event, error] {
+	return func(yield func(Event, error) bool) {
+		var event Event
+		data := &strings.Builder{}
+		pending := false
+
+		for line, err := range Lines(re, opts...) {
+			if err != nil {
+				yield(Event{}, err)
+				return
+			}
+
+			if line == "" {
+				if !pending {
+					continue
+				}
+
+				event.Data = strings.TrimSuffix(data.String(), "\n")
+				if !yield(event, nil) {
+					return
+				}
+
+				event, pending = Event{}, false
+				data.Reset()
+				continue
+			}
+
+			if strings.HasPrefix(line, ":") {
+				continue
+			}
+
+			field, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+
+			switch field {
+			case "id":
+				if strings.ContainsRune(value, 0) {
+					continue
+				}
+				event.ID = value
+			case "event":
+				event.Event = value
+			case "data":
+				data.WriteString(value)
+				data.WriteByte('\n')
+			case "retry":
+				ms, err := strconv.ParseUint(value, 10, 63)
+				if err != nil || ms > math.MaxInt64/uint64(time.Millisecond) {
+					continue
+				}
+				event.Retry = time.Duration(ms) * time.Millisecond
+			default:
+				continue
+			}
+
+			pending = true
+		}
+	}
+}
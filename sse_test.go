@@ -0,0 +1,242 @@
+package itermore_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ninedraft/itermore"
+)
+
+func ExampleWriteSSE() {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		events := func(context.Context) iter.Seq[itermore.Event] {
+			return itermore.Items(
+				itermore.Event{ID: "1", Event: "greeting", Data: "hello"},
+				itermore.Event{ID: "2", Data: "multi\nline"},
+			)
+		}
+
+		_ = itermore.WriteSSE(r.Context(), w, events)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		panic(err)
+	}
+	defer resp.Body.Close()
+
+	for event, err := range itermore.ReadSSE(resp.Body) {
+		if err != nil {
+			panic(err)
+		}
+		fmt.Printf("%s %q %q\n", event.ID, event.Event, event.Data)
+	}
+	// Output: 1 "greeting" "hello"
+	// 2 "" "multi\nline"
+}
+
+// sseEvents returns an events function for WriteSSE, which ignores the context.
+func sseEvents(events ...itermore.Event) func(context.Context) iter.Seq[itermore.Event] {
+	return func(context.Context) iter.Seq[itermore.Event] {
+		return itermore.Slice(events)
+	}
+}
+
+func TestWriteSSE(t *testing.T) {
+	t.Parallel()
+
+	t.Run("encoding", func(t *testing.T) {
+		t.Parallel()
+
+		rec := httptest.NewRecorder()
+		events := sseEvents(
+			itermore.Event{ID: "1", Event: "update", Data: "a\r\nb\nc", Retry: 1500 * time.Millisecond},
+			itermore.Event{Data: "plain"},
+			itermore.Event{ID: "3"},
+		)
+
+		ctx := context.Background()
+		if err := itermore.WriteSSE(ctx, rec, events); err != nil {
+			t.Fatal(err)
+		}
+
+		want := "id: 1\nevent: update\nretry: 1500\ndata: a\ndata: b\ndata: c\n\n" +
+			"data: plain\n\n" +
+			"id: 3\n\n"
+		if got := rec.Body.String(); got != want {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+
+		if got := rec.Header().Get("Content-Type"); got != "text/event-stream" {
+			t.Errorf("got content type %q", got)
+		}
+
+		if !rec.Flushed {
+			t.Error("response is not flushed")
+		}
+
+		err := itermore.WriteSSE(ctx, httptest.NewRecorder(), sseEvents(itermore.Event{Event: "a\nb"}))
+		if err == nil {
+			t.Error("expected error for malformed event")
+		}
+	})
+
+	t.Run("disconnect", func(t *testing.T) {
+		t.Parallel()
+
+		handlerErr := make(chan error, 1)
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			events := func(ctx context.Context) iter.Seq[itermore.Event] {
+				return func(yield func(itermore.Event) bool) {
+					for i := range itermore.Enumerate(itermore.TickCtx(ctx, time.Millisecond)) {
+						if !yield(itermore.Event{ID: fmt.Sprint(i), Data: "tick"}) {
+							return
+						}
+					}
+				}
+			}
+
+			handlerErr <- itermore.WriteSSE(r.Context(), w, events)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		t.Cleanup(server.Close)
+
+		resp, err := http.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var ids []string
+		for event, err := range itermore.ReadSSE(resp.Body) {
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids = append(ids, event.ID)
+			if len(ids) == 3 {
+				break
+			}
+		}
+		_ = resp.Body.Close()
+
+		if want := []string{"0", "1", "2"}; !slices.Equal(ids, want) {
+			t.Errorf("got ids %q, want %q", ids, want)
+		}
+
+		select {
+		case err := <-handlerErr:
+			if err == nil {
+				t.Error("expected error after client disconnect")
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("handler is not stopped after client disconnect")
+		}
+	})
+
+	t.Run("disconnect-blocked", func(t *testing.T) {
+		t.Parallel()
+
+		handlerErr := make(chan error, 1)
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			events := func(ctx context.Context) iter.Seq[itermore.Event] {
+				// the next event is never produced after the first one
+				ch := make(chan itermore.Event, 1)
+				ch <- itermore.Event{ID: "0", Data: "first"}
+
+				return itermore.ChanCtx(ctx, ch)
+			}
+
+			handlerErr <- itermore.WriteSSE(r.Context(), w, events)
+		}
+
+		server := httptest.NewServer(http.HandlerFunc(handler))
+		t.Cleanup(server.Close)
+
+		resp, err := http.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for event, err := range itermore.ReadSSE(resp.Body) {
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if event.ID != "0" {
+				t.Errorf("got id %q, want %q", event.ID, "0")
+			}
+			break
+		}
+		_ = resp.Body.Close()
+
+		select {
+		case err := <-handlerErr:
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("got %v, want %v", err, context.Canceled)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("handler is not stopped after client disconnect while the sequence is blocked")
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := itermore.WriteSSE(ctx, httptest.NewRecorder(), sseEvents(itermore.Event{Data: "a"}))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got %v, want %v", err, context.Canceled)
+		}
+	})
+}
+
+func TestReadSSE(t *testing.T) {
+	t.Parallel()
+
+	input := ": comment\n" +
+		"retry: 100\n\n" +
+		"id: 1\nevent: update\ndata:first\ndata:  second\n\n" +
+		"\n\n" +
+		"unknown: field\nretry: invalid\n\n" +
+		"data\r\n\r\n" +
+		"id: 2\ndata: incomplete"
+
+	assertBreak2(t, itermore.ReadSSE(strings.NewReader(input)))
+
+	got, err := collectSeqErr(itermore.ReadSSE(strings.NewReader(input)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []itermore.Event{
+		{Retry: 100 * time.Millisecond},
+		{ID: "1", Event: "update", Data: "first\n second"},
+		{Data: ""},
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("got:  %q", got)
+		t.Errorf("want: %q", want)
+	}
+
+	errTest := errors.New("test error")
+	_, err = collectSeqErr(itermore.ReadSSE(&errReader{err: errTest}))
+	if !errors.Is(err, errTest) {
+		t.Errorf("got %v, want %v", err, errTest)
+	}
+}
@@ -0,0 +1,133 @@
+package itermore
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Stream is a sequence with chainable methods.
+// It allows to write pipelines top to bottom instead of nesting function calls:
+//
+//	top := StreamOf(seq).
+//		Filter(isValid).
+//		SkipN(2).
+//		TakeN(5).
+//		Collect()
+//
+// Methods cover operations, which preserve the element type.
+// Operations, which change the element type, are available as free functions: StreamMap, StreamFlatMap.
+// Go methods can't introduce additional type constraints, so Compact and Max are provided
+// as free functions StreamCompact and StreamMax, and as CompactFunc and MaxFunc methods.
+//
+// Stream can be ranged over directly or converted back to iter.Seq with Seq method.
+type Stream[E any] iter.Seq[E]
+
+// StreamOf wraps the given sequence into a Stream.
+func StreamOf[E any](seq iter.Seq[E]) Stream[E] {
+	return Stream[E](seq)
+}
+
+// StreamMap returns a stream, which yields results of fn applied to each value of the given stream.
+func StreamMap[E, R any](s Stream[E], fn func(E) R) Stream[R] {
+	return func(yield func(R) bool) {
+		for value := range s {
+			if !yield(fn(value)) {
+				return
+			}
+		}
+	}
+}
+
+// StreamFlatMap returns a stream, which yields all values from sequences produced by fn for each value of the given stream.
+func StreamFlatMap[E, R any](s Stream[E], fn func(E) iter.Seq[R]) Stream[R] {
+	return func(yield func(R) bool) {
+		for value := range s {
+			if !YieldFrom(yield, fn(value)) {
+				return
+			}
+		}
+	}
+}
+
+// StreamCompact returns a stream, which skips consecutive equal values.
+// See Compact function.
+func StreamCompact[E comparable](s Stream[E]) Stream[E] {
+	return Stream[E](Compact(s.Seq()))
+}
+
+// StreamMax drains the stream and returns the largest value.
+// See Max function.
+func StreamMax[E cmp.Ordered](s Stream[E]) (E, bool) {
+	return Max(s.Seq())
+}
+
+// Seq returns the underlying sequence.
+func (s Stream[E]) Seq() iter.Seq[E] {
+	return iter.Seq[E](s)
+}
+
+// Filter yields only values that satisfy the given predicate.
+// See Filter function.
+func (s Stream[E]) Filter(pred func(E) bool) Stream[E] {
+	return Stream[E](Filter(s.Seq(), pred))
+}
+
+// SkipN skips first n values.
+// See SkipN function.
+func (s Stream[E]) SkipN(n int) Stream[E] {
+	return Stream[E](SkipN(n, s.Seq()))
+}
+
+// TakeN yields first n values.
+// See TakeN function.
+func (s Stream[E]) TakeN(n int) Stream[E] {
+	return Stream[E](TakeN(n, s.Seq()))
+}
+
+// CompactFunc skips consecutive values, which are equal according to eq.
+// See CompactFunc function.
+func (s Stream[E]) CompactFunc(eq func(a, b E) bool) Stream[E] {
+	return Stream[E](CompactFunc(s.Seq(), eq))
+}
+
+// Tee splits the stream into n independent streams.
+// See Tee function.
+func (s Stream[E]) Tee(n int) []Stream[E] {
+	seqs := Tee(s.Seq(), n)
+
+	streams := make([]Stream[E], 0, len(seqs))
+	for _, seq := range seqs {
+		streams = append(streams, Stream[E](seq))
+	}
+
+	return streams
+}
+
+// Buffered reads up to size values ahead in a separate goroutine.
+// See Buffered function.
+func (s Stream[E]) Buffered(size int) Stream[E] {
+	return Stream[E](Buffered(s.Seq(), size))
+}
+
+// Then calls the given function after the stream is drained or stopped.
+// See Then function.
+func (s Stream[E]) Then(then func()) Stream[E] {
+	return Stream[E](Then(s.Seq(), then))
+}
+
+// Collect drains the stream into a new slice.
+func (s Stream[E]) Collect() []E {
+	return slices.Collect(s.Seq())
+}
+
+// Count drains the stream and returns number of values in it.
+func (s Stream[E]) Count() int {
+	return Count(s.Seq())
+}
+
+// MaxFunc drains the stream and returns the largest value according to cmp.
+// See MaxFunc function.
+func (s Stream[E]) MaxFunc(cmp func(a, b E) int) (E, bool) {
+	return MaxFunc(s.Seq(), cmp)
+}
@@ -0,0 +1,126 @@
+package itermore_test
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/ninedraft/itermore"
+)
+
+func ExampleStream() {
+	isOdd := func(x int) bool { return x%2 != 0 }
+
+	top := itermore.StreamOf(itermore.For(0, 100, 1)).
+		Filter(isOdd).
+		SkipN(2).
+		TakeN(3).
+		Collect()
+
+	fmt.Println(top)
+	// Output: [5 7 9]
+}
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	assertBreak(t, itermore.StreamOf(itermore.Items(1, 2, 3)).Seq())
+
+	t.Run("chain", func(t *testing.T) {
+		t.Parallel()
+
+		thenCalled := false
+		eq := func(a, b int) bool { return a == b }
+
+		got := itermore.StreamOf(itermore.Items(1, 1, 2, 3, 3, 4, 5, 6)).
+			CompactFunc(eq).
+			Filter(func(x int) bool { return x > 1 }).
+			SkipN(1).
+			TakeN(3).
+			Buffered(1).
+			Then(func() { thenCalled = true }).
+			Collect()
+
+		want := []int{3, 4, 5}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+
+		if !thenCalled {
+			t.Error("then function must be called")
+		}
+	})
+
+	t.Run("terminal", func(t *testing.T) {
+		t.Parallel()
+
+		s := itermore.StreamOf(itermore.Items(3, 1, 4, 1, 5))
+
+		if got := s.Count(); got != 5 {
+			t.Errorf("count: got %d, want %d", got, 5)
+		}
+
+		if got, ok := s.MaxFunc(cmp.Compare[int]); !ok || got != 5 {
+			t.Errorf("max: got %d, %v; want 5, true", got, ok)
+		}
+
+		if got, ok := itermore.StreamMax(s); !ok || got != 5 {
+			t.Errorf("stream max: got %d, %v; want 5, true", got, ok)
+		}
+
+		if _, ok := itermore.StreamMax(itermore.StreamOf(itermore.Items[int]())); ok {
+			t.Errorf("stream max of empty stream: got ok")
+		}
+	})
+
+	t.Run("compact", func(t *testing.T) {
+		t.Parallel()
+
+		got := itermore.StreamCompact(itermore.StreamOf(itermore.Items(1, 1, 2, 2, 2, 1, 3, 3))).
+			TakeN(3).
+			Collect()
+
+		want := []int{1, 2, 1}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("tee", func(t *testing.T) {
+		t.Parallel()
+
+		streams := itermore.StreamOf(itermore.Items(1, 2, 3)).Tee(2)
+		if len(streams) != 2 {
+			t.Fatalf("got %d streams, want 2", len(streams))
+		}
+
+		want := []int{1, 2, 3}
+		for _, s := range streams {
+			if got := s.Collect(); !slices.Equal(got, want) {
+				t.Errorf("got:  %v", got)
+				t.Errorf("want: %v", want)
+			}
+		}
+	})
+
+	t.Run("map", func(t *testing.T) {
+		t.Parallel()
+
+		s := itermore.StreamOf(itermore.Items(1, 2))
+		got := itermore.StreamFlatMap(
+			itermore.StreamMap(s, strconv.Itoa),
+			func(x string) iter.Seq[string] { return itermore.Items(x, x+x) },
+		).Collect()
+
+		want := []string{"1", "11", "2", "22"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+}
@@ -1,9 +1,13 @@
 package itermore
 
 import (
+	"bufio"
+	"bytes"
 	"errors"
 	"io"
 	"iter"
+	"reflect"
+	"unicode/utf8"
 )
 
 // CollectJoin writes values from provided sequence to the given writer.
@@ -114,6 +118,8 @@ func CollectJoinReaders[R io.Reader](wr io.Writer, seq iter.Seq[R], sep []byte)
 // readers from the provided sequence. They're read sequentially. Once all
 // readers have returned EOF, Read will return EOF. If any of the readers
 // return a non-nil, non-EOF error, Read will return that error.
+// As soon as the current reader returns EOF, the next reader is pulled from the sequence,
+// so EOF is reported together with the last chunk of data, as io.MultiReader does.
 // It is an analog of io.MultiReader, but for iter.Seq[io.Reader].
 // The caller should call Close on the returned reader to release resources
 // associated with the iterator.
@@ -162,8 +168,272 @@ next:
 	}
 
 	if isEOF && n != 0 {
+		// report EOF together with the last chunk of data, as io.MultiReader does
+		re, ok := mr.next()
+		if !ok {
+			mr.stop()
+			return n, io.EOF
+		}
+		mr.re = re
+
 		return n, nil
 	}
 
 	return n, err
 }
+
+// WriteTo implements io.WriterTo.
+// It copies readers directly to w, so io.Copy can avoid an intermediate buffer.
+func (mr *multiReader) WriteTo(wr io.Writer) (int64, error) {
+	written := int64(0)
+
+	var buf []byte
+	_, isReaderFrom := wr.(io.ReaderFrom)
+
+	for {
+		if mr.re == nil {
+			re, ok := mr.next()
+			if !ok {
+				mr.stop()
+				return written, nil
+			}
+
+			mr.re = re
+		}
+
+		if _, isWriterTo := mr.re.(io.WriterTo); !isWriterTo && !isReaderFrom && buf == nil {
+			buf = make([]byte, defaultBufferSize)
+		}
+
+		n, err := io.CopyBuffer(wr, mr.re, buf)
+		written += n
+		if err != nil {
+			return written, err
+		}
+
+		mr.re = nil
+	}
+}
+
+// SeqReader returns an io.ReadCloser, which reads data from the sequence of chunks.
+// The returned reader also implements io.WriterTo, so io.Copy writes chunks directly
+// to the destination without an intermediate buffer.
+// Chunks are not copied or retained after they are read.
+// The caller should call Close on the returned reader to release resources
+// associated with the iterator.
+func SeqReader[P ~[]byte | ~string](seq iter.Seq[P]) io.ReadCloser {
+	next, stop := iter.Pull(seq)
+
+	return &seqReader[P]{
+		next:     next,
+		stop:     stop,
+		isString: reflect.TypeFor[P]().Kind() == reflect.String,
+	}
+}
+
+type seqReader[P ~[]byte | ~string] struct {
+	chunk    P
+	next     func() (P, bool)
+	stop     func()
+	isString bool
+}
+
+func (sr *seqReader[P]) Close() error {
+	sr.stop()
+	return nil
+}
+
+// pull fetches next non-empty chunk.
+func (sr *seqReader[P]) pull() bool {
+	for len(sr.chunk) == 0 {
+		chunk, ok := sr.next()
+		if !ok {
+			// stop the iterator earlier to help GC
+			sr.stop()
+			return false
+		}
+
+		sr.chunk = chunk
+	}
+
+	return true
+}
+
+func (sr *seqReader[P]) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if !sr.pull() {
+		return 0, io.EOF
+	}
+
+	n := copy(p, sr.chunk)
+	sr.chunk = sr.chunk[n:]
+
+	return n, nil
+}
+
+// WriteTo implements io.WriterTo.
+func (sr *seqReader[P]) WriteTo(wr io.Writer) (int64, error) {
+	written := int64(0)
+
+	for sr.pull() {
+		var n int
+		var err error
+
+		// conversions are no-op for the matching kind
+		if sr.isString {
+			n, err = io.WriteString(wr, string(sr.chunk))
+		} else {
+			n, err = wr.Write([]byte(sr.chunk))
+		}
+
+		written += int64(n)
+		sr.chunk = sr.chunk[n:]
+
+		if err == nil && len(sr.chunk) > 0 {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			return written, err
+		}
+	}
+
+	return written, nil
+}
+
+// ScanOption configures sequences, which read tokens from io.Reader.
+type ScanOption func(*scanConfig)
+
+type scanConfig struct {
+	maxTokenSize    int
+	keepLineEndings bool
+}
+
+func newScanConfig(opts []ScanOption) *scanConfig {
+	cfg := &scanConfig{
+		maxTokenSize: bufio.MaxScanTokenSize,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return cfg
+}
+
+// WithMaxTokenSize sets the maximum size of a single token, bufio.MaxScanTokenSize by default.
+// If a token is longer, the sequence stops with bufio.ErrTooLong.
+func WithMaxTokenSize(size int) ScanOption {
+	return func(cfg *scanConfig) {
+		cfg.maxTokenSize = size
+	}
+}
+
+// WithLineEndings makes Lines and LinesBytes keep line endings in yielded lines.
+// The last line may have no line ending. It is ignored by other functions.
+func WithLineEndings() ScanOption {
+	return func(cfg *scanConfig) {
+		cfg.keepLineEndings = true
+	}
+}
+
+// Scan creates a sequence that yields tokens from the given reader, split by the given function.
+// It is a wrapper around bufio.Scanner.
+//
+// The sequence is error-aware: if reading fails, it yields the error with a nil token as its last pair.
+// Reaching io.EOF is not reported as an error.
+// Yielded tokens share the underlying buffer and are valid until the next iteration.
+func Scan(re io.Reader, split bufio.SplitFunc, opts ...ScanOption) iter.Seq2[[]byte, error] {
+	cfg := newScanConfig(opts)
+
+	return func(yield func([]byte, error) bool) {
+		scanner := bufio.NewScanner(re)
+		scanner.Buffer(nil, cfg.maxTokenSize)
+		scanner.Split(split)
+
+		for scanner.Scan() {
+			if !yield(scanner.Bytes(), nil) {
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// Lines creates a sequence that yields lines from the given reader.
+// Line endings are stripped unless WithLineEndings option is provided.
+// It is an inverse of CollectJoin with "\n" separator.
+// See Scan for error handling.
+func Lines(re io.Reader, opts ...ScanOption) iter.Seq2[string, error] {
+	return scanStrings(LinesBytes(re, opts...))
+}
+
+// LinesBytes creates a sequence that yields lines from the given reader.
+// Unlike Lines, it reuses the underlying buffer: yielded lines are valid until the next iteration.
+// It is an inverse of CollectJoinBytes with "\n" separator.
+// See Scan for error handling.
+func LinesBytes(re io.Reader, opts ...ScanOption) iter.Seq2[[]byte, error] {
+	split := bufio.ScanLines
+	if newScanConfig(opts).keepLineEndings {
+		split = scanLinesWithEndings
+	}
+
+	return Scan(re, split, opts...)
+}
+
+// Words creates a sequence that yields space-separated words from the given reader.
+// See bufio.ScanWords for the definition of a word and Scan for error handling.
+func Words(re io.Reader, opts ...ScanOption) iter.Seq2[string, error] {
+	return scanStrings(Scan(re, bufio.ScanWords, opts...))
+}
+
+// Runes creates a sequence that yields UTF-8 decoded runes from the given reader.
+// Invalid encodings are yielded as utf8.RuneError.
+// See Scan for error handling.
+func Runes(re io.Reader, opts ...ScanOption) iter.Seq2[rune, error] {
+	return func(yield func(rune, error) bool) {
+		for token, err := range Scan(re, bufio.ScanRunes, opts...) {
+			if err != nil {
+				yield(0, err)
+				return
+			}
+
+			r, _ := utf8.DecodeRune(token)
+			if !yield(r, nil) {
+				return
+			}
+		}
+	}
+}
+
+func scanStrings(seq iter.Seq2[[]byte, error]) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		for token, err := range seq {
+			if !yield(string(token), err) {
+				return
+			}
+		}
+	}
+}
+
+// scanLinesWithEndings is like bufio.ScanLines, but keeps line endings.
+func scanLinesWithEndings(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return i + 1, data[:i+1], nil
+	}
+
+	if atEOF {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
@@ -1,15 +1,19 @@
 package itermore_test
 
 import (
+	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"iter"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
+	"unicode/utf8"
 
 	"github.com/ninedraft/itermore"
 )
@@ -418,3 +422,339 @@ func TestInterleavedMultiReader(t *testing.T) {
 		t.Errorf(`ReadFull(mr1) = (%q, %v), want ("5678", nil)`, got, err)
 	}
 }
+
+// collectSeqErr collects values from an error-aware sequence.
+func collectSeqErr[E any](seq iter.Seq2[E, error]) ([]E, error) {
+	var values []E
+	for value, err := range seq {
+		if err != nil {
+			return values, err
+		}
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+func ExampleLines() {
+	input := strings.NewReader("first\nsecond\nthird")
+
+	for line, err := range itermore.Lines(input) {
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(line)
+	}
+	// Output: first
+	// second
+	// third
+}
+
+func TestLines(t *testing.T) {
+	t.Parallel()
+
+	assertBreak2(t, itermore.Lines(strings.NewReader("a\nb")))
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := collectSeqErr(itermore.Lines(strings.NewReader("a\r\nb\n\nc")))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"a", "b", "", "c"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+	})
+
+	t.Run("line-endings", func(t *testing.T) {
+		t.Parallel()
+
+		input := "a\r\nb\n\nc"
+		got, err := collectSeqErr(itermore.Lines(strings.NewReader(input), itermore.WithLineEndings()))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"a\r\n", "b\n", "\n", "c"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+
+		if joined := strings.Join(got, ""); joined != input {
+			t.Errorf("joined lines must be equal to input, got %q", joined)
+		}
+	})
+
+	t.Run("too-long", func(t *testing.T) {
+		t.Parallel()
+
+		input := strings.NewReader("short\n" + strings.Repeat("x", 100) + "\nshort")
+		got, err := collectSeqErr(itermore.Lines(input, itermore.WithMaxTokenSize(16)))
+
+		if !errors.Is(err, bufio.ErrTooLong) {
+			t.Fatalf("got %v, want %v", err, bufio.ErrTooLong)
+		}
+
+		if want := []string{"short"}; !slices.Equal(got, want) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+	})
+
+	t.Run("read-error", func(t *testing.T) {
+		t.Parallel()
+
+		errTest := errors.New("test error")
+		input := io.MultiReader(strings.NewReader("a\nb"), &errReader{err: errTest})
+
+		got, err := collectSeqErr(itermore.Lines(input))
+		if !errors.Is(err, errTest) {
+			t.Fatalf("got %v, want %v", err, errTest)
+		}
+
+		// the trailing line is yielded before the error, as bufio.Scanner does
+		if want := []string{"a", "b"}; !slices.Equal(got, want) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+	})
+}
+
+func TestLinesBytes(t *testing.T) {
+	t.Parallel()
+
+	assertBreak2(t, itermore.LinesBytes(strings.NewReader("a\nb")))
+
+	lines := itermore.Items([]byte("a"), []byte("b"), []byte("c"))
+	buf := &bytes.Buffer{}
+	if _, err := itermore.CollectJoinBytes(buf, lines, []byte("\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	got := []string{}
+	for line, err := range itermore.LinesBytes(buf) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(line))
+	}
+
+	want := []string{"a", "b", "c"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got:  %q", got)
+		t.Errorf("want: %q", want)
+	}
+}
+
+func TestWords(t *testing.T) {
+	t.Parallel()
+
+	assertBreak2(t, itermore.Words(strings.NewReader("a b")))
+
+	got, err := collectSeqErr(itermore.Words(strings.NewReader("  the quick\n\tbrown  fox ")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"the", "quick", "brown", "fox"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got:  %q", got)
+		t.Errorf("want: %q", want)
+	}
+}
+
+func TestRunes(t *testing.T) {
+	t.Parallel()
+
+	assertBreak2(t, itermore.Runes(strings.NewReader("ab")))
+
+	got, err := collectSeqErr(itermore.Runes(strings.NewReader("añ世\xff")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []rune{'a', 'ñ', '世', utf8.RuneError}
+	if !slices.Equal(got, want) {
+		t.Errorf("got:  %q", got)
+		t.Errorf("want: %q", want)
+	}
+}
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+
+	assertBreak2(t, itermore.Scan(strings.NewReader("ab"), bufio.ScanBytes))
+
+	got := []string{}
+	for token, err := range itermore.Scan(strings.NewReader("abc"), bufio.ScanBytes) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(token))
+	}
+
+	want := []string{"a", "b", "c"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got:  %q", got)
+		t.Errorf("want: %q", want)
+	}
+}
+
+type errReader struct{ err error }
+
+func (er *errReader) Read([]byte) (int, error) {
+	return 0, er.err
+}
+
+func ExampleSeqReader() {
+	chunks := itermore.Items("hello", ", ", "world")
+
+	re := itermore.SeqReader(chunks)
+	defer re.Close()
+
+	data, _ := io.ReadAll(re)
+	fmt.Println(string(data))
+	// Output: hello, world
+}
+
+// chunkWriter records each Write call. It implements neither io.ReaderFrom nor io.StringWriter.
+type chunkWriter struct{ chunks []string }
+
+func (cw *chunkWriter) Write(p []byte) (int, error) {
+	cw.chunks = append(cw.chunks, string(p))
+	return len(p), nil
+}
+
+func TestSeqReader(t *testing.T) {
+	t.Parallel()
+
+	t.Run("read", func(t *testing.T) {
+		t.Parallel()
+
+		re := itermore.SeqReader(itermore.Items([]byte("ab"), nil, []byte("cde")))
+		t.Cleanup(func() { _ = re.Close() })
+
+		got := []string{}
+		buf := make([]byte, 2)
+		for {
+			n, err := re.Read(buf)
+			if n > 0 {
+				got = append(got, string(buf[:n]))
+			}
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		want := []string{"ab", "cd", "e"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+	})
+
+	t.Run("write-to", func(t *testing.T) {
+		t.Parallel()
+
+		type text string
+
+		re := itermore.SeqReader(itermore.Items[text]("ab", "", "cde"))
+		t.Cleanup(func() { _ = re.Close() })
+
+		wr := &chunkWriter{}
+		n, err := io.Copy(wr, re)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n != 5 {
+			t.Errorf("got %d, want %d", n, 5)
+		}
+
+		// chunks are written as is, without intermediate buffer
+		want := []string{"ab", "cde"}
+		if !slices.Equal(wr.chunks, want) {
+			t.Errorf("got:  %q", wr.chunks)
+			t.Errorf("want: %q", want)
+		}
+	})
+
+	t.Run("write-error", func(t *testing.T) {
+		t.Parallel()
+
+		errTest := errors.New("test error")
+
+		re := itermore.SeqReader(itermore.Items("a", "b"))
+		t.Cleanup(func() { _ = re.Close() })
+
+		_, err := io.Copy(&errWriter{err: errTest}, re)
+		if !errors.Is(err, errTest) {
+			t.Fatalf("got %v, want %v", err, errTest)
+		}
+	})
+
+	t.Run("close", func(t *testing.T) {
+		t.Parallel()
+
+		stopped := false
+		seq := itermore.Then(itermore.Forever("x"), func() { stopped = true })
+
+		re := itermore.SeqReader(seq)
+		if _, err := re.Read(make([]byte, 4)); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := re.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !stopped {
+			t.Fatal("sequence must be stopped after Close")
+		}
+	})
+}
+
+func TestMultiReaderWriteTo(t *testing.T) {
+	t.Parallel()
+
+	mr := mkMultiReaderRC(
+		strings.NewReader("foo "),
+		iotest.OneByteReader(strings.NewReader("bar")),
+		strings.NewReader(""),
+		strings.NewReader(" baz"),
+	)
+	t.Cleanup(func() { _ = mr.Close() })
+
+	if _, ok := mr.(io.WriterTo); !ok {
+		t.Fatal("MultiReader must implement io.WriterTo")
+	}
+
+	// read a bit first to check that WriteTo continues from the current reader
+	buf := make([]byte, 2)
+	if _, err := io.ReadFull(mr, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	wr := &chunkWriter{}
+	n, err := io.Copy(wr, mr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := string(buf) + strings.Join(wr.chunks, "")
+	if want := "foo bar baz"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if n != 9 {
+		t.Errorf("got %d, want %d", n, 9)
+	}
+}
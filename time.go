@@ -32,6 +32,19 @@ func TickCtx(ctx context.Context, dt time.Duration) iter.Seq[time.Time] {
 	}
 }
 
+// TickCtxStatus is like TickCtx, but it also returns a status, which reports why the iteration has stopped:
+// ctx is canceled or the consumer has stopped. See ChanCtxStatus for details.
+func TickCtxStatus(ctx context.Context, dt time.Duration) (iter.Seq[time.Time], *StopStatus) {
+	status := &StopStatus{}
+
+	return func(yield func(time.Time) bool) {
+		ticker := time.NewTicker(dt)
+		defer ticker.Stop()
+
+		YieldFrom(yield, chanCtx(ctx, ticker.C, status))
+	}, status
+}
+
 // Timer creates and immediately starts a timer.
 // Returned seq emits timestamps and a reset function, which can be used to set next timer timeout.
 // If reset function is not called, then seq is stopped.
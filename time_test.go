@@ -2,6 +2,7 @@ package itermore_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"runtime"
 	"testing"
@@ -104,3 +105,32 @@ func assertGoroutineLeak(t *testing.T) func() {
 		}
 	}
 }
+
+func TestTickCtxStatus(t *testing.T) {
+	defer assertGoroutineLeak(t)()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	seq, status := itermore.TickCtxStatus(ctx, time.Millisecond)
+
+	i := 0
+	for range seq {
+		i++
+		if i > 3 {
+			break
+		}
+	}
+
+	if status.Reason() != itermore.StopConsumer {
+		t.Errorf("got %v, want %v", status.Reason(), itermore.StopConsumer)
+	}
+
+	for range seq {
+		cancel()
+	}
+
+	if status.Reason() != itermore.StopCanceled || !errors.Is(status.Err(), context.Canceled) {
+		t.Errorf("got %v %v, want %v", status.Reason(), status.Err(), itermore.StopCanceled)
+	}
+}
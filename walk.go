@@ -0,0 +1,316 @@
+package itermore
+
+import (
+	"errors"
+	"io/fs"
+	"iter"
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+// WalkOption configures Walk.
+type WalkOption func(*walkConfig)
+
+type walkConfig struct {
+	maxDepth int
+	include  []string
+	exclude  []string
+	skip     func(path string, entry fs.DirEntry) error
+	follow   bool
+	workers  int
+}
+
+// WithMaxDepth limits the depth of the walk. The root has depth 0,
+// its direct children have depth 1 and so on.
+// It will panic if depth is negative.
+func WithMaxDepth(depth int) WalkOption {
+	if depth < 0 {
+		panic("itermore.WithMaxDepth: depth must not be negative")
+	}
+
+	return func(cfg *walkConfig) {
+		cfg.maxDepth = depth
+	}
+}
+
+// WithInclude makes Walk yield only entries matching any of the given glob patterns (see path.Match).
+// Patterns containing '/' are matched against the full path, other patterns are matched against the base name.
+// Directories are walked regardless of the patterns.
+// It will panic if any pattern is malformed.
+func WithInclude(patterns ...string) WalkOption {
+	patterns = validPatterns("itermore.WithInclude", patterns)
+
+	return func(cfg *walkConfig) {
+		cfg.include = append(cfg.include, patterns...)
+	}
+}
+
+// WithExclude makes Walk skip entries matching any of the given glob patterns.
+// Contents of excluded directories are skipped as well.
+// Patterns are matched the same way as in WithInclude.
+// It will panic if any pattern is malformed.
+func WithExclude(patterns ...string) WalkOption {
+	patterns = validPatterns("itermore.WithExclude", patterns)
+
+	return func(cfg *walkConfig) {
+		cfg.exclude = append(cfg.exclude, patterns...)
+	}
+}
+
+// WithSkipFunc sets a function, which is called for each entry before it is yielded.
+// If it returns fs.SkipDir, the entry is skipped, and its contents as well if it is a directory.
+// If it returns fs.SkipAll, the walk stops. Other errors stop the walk and are reported by Walk.
+func WithSkipFunc(skip func(path string, entry fs.DirEntry) error) WalkOption {
+	return func(cfg *walkConfig) {
+		cfg.skip = skip
+	}
+}
+
+// WithFollowSymlinks makes Walk follow symbolic links to directories.
+// The file system must resolve symbolic links in fs.Stat, like os.DirFS does.
+// Links, which point to one of their parent directories, are yielded, but not followed.
+func WithFollowSymlinks() WalkOption {
+	return func(cfg *walkConfig) {
+		cfg.follow = true
+	}
+}
+
+// WithParallelStat makes Walk fetch file info of directory entries using n concurrent workers,
+// before entries are yielded. It speeds up walks, which call fs.DirEntry.Info for most of entries,
+// on file systems with slow stat calls.
+// It will panic if n is not positive.
+func WithParallelStat(n int) WalkOption {
+	if n <= 0 {
+		panic("itermore.WithParallelStat: number of workers must be positive")
+	}
+
+	return func(cfg *walkConfig) {
+		cfg.workers = n
+	}
+}
+
+// Walk creates a sequence, which walks the file tree rooted at root and yields paths and entries of files
+// and directories, including the root. Entries are yielded in lexical order, directories are yielded before their contents.
+// The walk reads directories lazily and stops as soon as the consumer breaks the iteration.
+//
+// The returned function reports the error, which stopped the last finished walk.
+// The sequence can be iterated multiple times, also concurrently, each iteration walks the tree anew.
+// Errors returned by WithSkipFunc function, except fs.SkipDir and fs.SkipAll, are reported as well.
+func Walk(fsys fs.FS, root string, opts ...WalkOption) (iter.Seq2[string, fs.DirEntry], func() error) {
+	cfg := &walkConfig{
+		maxDepth: -1,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	var (
+		mu      sync.Mutex
+		walkErr error
+	)
+
+	seq := func(yield func(string, fs.DirEntry) bool) {
+		err := walk(fsys, root, cfg, yield)
+
+		mu.Lock()
+		walkErr = err
+		mu.Unlock()
+	}
+
+	report := func() error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return walkErr
+	}
+
+	return seq, report
+}
+
+func walk(fsys fs.FS, root string, cfg *walkConfig, yield func(string, fs.DirEntry) bool) error {
+	info, err := fs.Stat(fsys, root)
+	if err != nil {
+		return err
+	}
+
+	w := &walker{
+		fsys:  fsys,
+		cfg:   cfg,
+		yield: yield,
+	}
+
+	dirInfo := info
+	if !info.IsDir() {
+		// the root is a file, it is yielded without reading
+		dirInfo = nil
+	}
+
+	_, err = w.walk(root, fs.FileInfoToDirEntry(info), dirInfo, 0)
+	if errors.Is(err, fs.SkipAll) || errors.Is(err, fs.SkipDir) {
+		return nil
+	}
+
+	return err
+}
+
+type walker struct {
+	fsys  fs.FS
+	cfg   *walkConfig
+	yield func(string, fs.DirEntry) bool
+	// parents are infos of directories on the current path, used to detect symlink cycles.
+	parents []fs.FileInfo
+}
+
+// walk visits the entry and its contents.
+// It returns false, if the walk must be stopped.
+// info is non-nil only for directories, which are known to be walked.
+func (w *walker) walk(name string, entry fs.DirEntry, info fs.FileInfo, depth int) (bool, error) {
+	if w.cfg.excluded(name) {
+		return true, nil
+	}
+
+	if w.cfg.skip != nil {
+		switch err := w.cfg.skip(name, entry); {
+		case errors.Is(err, fs.SkipDir):
+			return true, nil
+		case err != nil:
+			return false, err
+		}
+	}
+
+	if w.cfg.included(name) && !w.yield(name, entry) {
+		return false, nil
+	}
+
+	if w.cfg.maxDepth >= 0 && depth >= w.cfg.maxDepth {
+		return true, nil
+	}
+
+	if info == nil {
+		var err error
+		info, err = w.dirInfo(name, entry)
+		if info == nil || err != nil {
+			return err == nil, err
+		}
+	}
+
+	for _, parent := range w.parents {
+		if os.SameFile(parent, info) {
+			// symlink cycle
+			return true, nil
+		}
+	}
+
+	entries, err := fs.ReadDir(w.fsys, name)
+	if err != nil {
+		return false, err
+	}
+
+	if w.cfg.workers > 0 {
+		entries = statEntries(entries, w.cfg.workers)
+	}
+
+	w.parents = append(w.parents, info)
+	defer func() { w.parents = w.parents[:len(w.parents)-1] }()
+
+	for _, child := range entries {
+		ok, err := w.walk(path.Join(name, child.Name()), child, nil, depth+1)
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// dirInfo returns info of the directory, which must be walked, or nil if the entry is not a walkable directory.
+func (w *walker) dirInfo(name string, entry fs.DirEntry) (fs.FileInfo, error) {
+	switch {
+	case entry.IsDir():
+		return entry.Info()
+	case w.cfg.follow && entry.Type()&fs.ModeSymlink != 0:
+		info, err := fs.Stat(w.fsys, name)
+		if err != nil || !info.IsDir() {
+			// broken links and links to files are yielded as is
+			return nil, nil
+		}
+		return info, nil
+	default:
+		return nil, nil
+	}
+}
+
+func (cfg *walkConfig) excluded(name string) bool {
+	return matchAny(cfg.exclude, name)
+}
+
+func (cfg *walkConfig) included(name string) bool {
+	return len(cfg.include) == 0 || matchAny(cfg.include, name)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		target := name
+		if !strings.Contains(pattern, "/") {
+			target = path.Base(name)
+		}
+
+		// patterns are validated by options
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validPatterns(caller string, patterns []string) []string {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			panic(caller + ": malformed pattern " + pattern)
+		}
+	}
+
+	return append([]string{}, patterns...)
+}
+
+// statEntries fetches info of entries using n concurrent workers.
+func statEntries(entries []fs.DirEntry, n int) []fs.DirEntry {
+	stated := make([]fs.DirEntry, len(entries))
+
+	indexes := make(chan int)
+	wg := &sync.WaitGroup{}
+
+	for range min(n, len(entries)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				info, err := entries[i].Info()
+				stated[i] = &statedEntry{DirEntry: entries[i], info: info, err: err}
+			}
+		}()
+	}
+
+	for i := range entries {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return stated
+}
+
+// statedEntry is a directory entry with prefetched info.
+type statedEntry struct {
+	fs.DirEntry
+	info fs.FileInfo
+	err  error
+}
+
+func (se *statedEntry) Info() (fs.FileInfo, error) {
+	return se.info, se.err
+}
@@ -0,0 +1,350 @@
+package itermore_test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ninedraft/itermore"
+)
+
+func ExampleWalk() {
+	fsys := fstest.MapFS{
+		"docs/readme.md":   {},
+		"src/main.go":      {},
+		"src/main_test.go": {},
+		"src/util/util.go": {},
+	}
+
+	files, walkErr := itermore.Walk(fsys, ".",
+		itermore.WithInclude("*.go"),
+		itermore.WithExclude("*_test.go"))
+
+	for name := range files {
+		fmt.Println(name)
+	}
+
+	if err := walkErr(); err != nil {
+		panic(err)
+	}
+	// Output: src/main.go
+	// src/util/util.go
+}
+
+var walkFS = fstest.MapFS{
+	"a/b/c.txt":  {},
+	"a/b/d.go":   {},
+	"a/e.go":     {},
+	"f.txt":      {},
+	"g/h/i/j.go": {},
+}
+
+// walkPaths collects paths yielded by Walk and reports the walk error.
+func walkPaths(fsys fs.FS, root string, opts ...itermore.WalkOption) ([]string, error) {
+	seq, walkErr := itermore.Walk(fsys, root, opts...)
+
+	var paths []string
+	for name := range seq {
+		paths = append(paths, name)
+	}
+
+	return paths, walkErr()
+}
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	seq, _ := itermore.Walk(walkFS, ".")
+	assertBreak2(t, seq)
+
+	tests := []struct {
+		name string
+		root string
+		opts []itermore.WalkOption
+		want []string
+	}{
+		{
+			name: "all",
+			root: ".",
+			want: []string{".", "a", "a/b", "a/b/c.txt", "a/b/d.go", "a/e.go", "f.txt", "g", "g/h", "g/h/i", "g/h/i/j.go"},
+		},
+		{
+			name: "subtree",
+			root: "a/b",
+			want: []string{"a/b", "a/b/c.txt", "a/b/d.go"},
+		},
+		{
+			name: "file-root",
+			root: "f.txt",
+			want: []string{"f.txt"},
+		},
+		{
+			name: "max-depth",
+			root: ".",
+			opts: []itermore.WalkOption{itermore.WithMaxDepth(1)},
+			want: []string{".", "a", "f.txt", "g"},
+		},
+		{
+			name: "max-depth-root",
+			root: "a",
+			opts: []itermore.WalkOption{itermore.WithMaxDepth(0)},
+			want: []string{"a"},
+		},
+		{
+			name: "include",
+			root: ".",
+			opts: []itermore.WalkOption{itermore.WithInclude("*.go")},
+			want: []string{"a/b/d.go", "a/e.go", "g/h/i/j.go"},
+		},
+		{
+			name: "include-path",
+			root: ".",
+			opts: []itermore.WalkOption{itermore.WithInclude("a/*/*")},
+			want: []string{"a/b/c.txt", "a/b/d.go"},
+		},
+		{
+			name: "exclude-dir",
+			root: ".",
+			opts: []itermore.WalkOption{itermore.WithExclude("b", "g")},
+			want: []string{".", "a", "a/e.go", "f.txt"},
+		},
+		{
+			name: "skip-func",
+			root: ".",
+			opts: []itermore.WalkOption{itermore.WithSkipFunc(func(name string, entry fs.DirEntry) error {
+				switch name {
+				case "a/b", "f.txt":
+					return fs.SkipDir
+				case "g/h":
+					return fs.SkipAll
+				}
+				return nil
+			})},
+			want: []string{".", "a", "a/e.go", "g"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := walkPaths(walkFS, tc.root, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("got:  %q", got)
+				t.Errorf("want: %q", tc.want)
+			}
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := walkPaths(walkFS, "missing")
+		if !errors.Is(err, fs.ErrNotExist) {
+			t.Errorf("got %v, want %v", err, fs.ErrNotExist)
+		}
+
+		errTest := errors.New("test error")
+		got, err := walkPaths(walkFS, ".", itermore.WithSkipFunc(func(name string, _ fs.DirEntry) error {
+			if name == "a/b" {
+				return errTest
+			}
+			return nil
+		}))
+
+		if !errors.Is(err, errTest) {
+			t.Errorf("got %v, want %v", err, errTest)
+		}
+
+		if want := []string{".", "a"}; !slices.Equal(got, want) {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("file-root-os", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "a.txt"), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := walkPaths(os.DirFS(dir), "a.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if want := []string{"a.txt"}; !slices.Equal(got, want) {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		t.Parallel()
+
+		seq, walkErr := itermore.Walk(walkFS, ".")
+
+		wg := &sync.WaitGroup{}
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if n := itermore.Count(itermore.KeysOf(seq)); n != 11 {
+					t.Errorf("got %d entries, want %d", n, 11)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if err := walkErr(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("break", func(t *testing.T) {
+		t.Parallel()
+
+		visited := 0
+		seq, walkErr := itermore.Walk(walkFS, ".", itermore.WithSkipFunc(func(string, fs.DirEntry) error {
+			visited++
+			return nil
+		}))
+
+		for name := range seq {
+			if name == "a/b" {
+				break
+			}
+		}
+
+		if visited != 3 {
+			t.Errorf("visited %d entries after break, want %d", visited, 3)
+		}
+
+		if err := walkErr(); err != nil {
+			t.Error(err)
+		}
+	})
+}
+
+func TestWalkParallelStat(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{}
+	for i := range 20 {
+		fsys[fmt.Sprintf("dir/file%02d", i)] = &fstest.MapFile{Data: make([]byte, i)}
+	}
+
+	seq, walkErr := itermore.Walk(fsys, "dir", itermore.WithParallelStat(4))
+
+	var sizes []int64
+	for _, entry := range seq {
+		info, err := entry.Info()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !entry.IsDir() {
+			sizes = append(sizes, info.Size())
+		}
+	}
+
+	if err := walkErr(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, size := range sizes {
+		if size != int64(i) {
+			t.Errorf("file %d: got size %d", i, size)
+		}
+	}
+
+	if len(sizes) != 20 {
+		t.Errorf("got %d files, want %d", len(sizes), 20)
+	}
+}
+
+func TestWalkSymlinks(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	mustMkdir := func(name string) {
+		if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mustSymlink := func(target, name string) {
+		if err := os.Symlink(target, filepath.Join(dir, name)); err != nil {
+			t.Skipf("symlinks are not supported: %v", err)
+		}
+	}
+
+	mustMkdir("data/sub")
+	if err := os.WriteFile(filepath.Join(dir, "data/sub/file.txt"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	mustMkdir("root")
+	mustSymlink("../data", "root/link")
+	mustSymlink("..", "data/sub/parent")
+	mustSymlink("missing", "root/broken")
+
+	fsys := os.DirFS(dir)
+
+	t.Run("not-followed", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := walkPaths(fsys, "root")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"root", "root/broken", "root/link"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+	})
+
+	t.Run("followed", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := walkPaths(fsys, "root", itermore.WithFollowSymlinks())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{
+			"root", "root/broken", "root/link",
+			"root/link/sub", "root/link/sub/file.txt", "root/link/sub/parent",
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := walkPaths(fsys, "data", itermore.WithFollowSymlinks(), itermore.WithParallelStat(2))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"data", "data/sub", "data/sub/file.txt", "data/sub/parent"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+	})
+}
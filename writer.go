@@ -0,0 +1,223 @@
+package itermore
+
+import (
+	"bytes"
+	"io"
+	"iter"
+)
+
+// WriterSeqOption configures WriterSeq.
+type WriterSeqOption func(*writerSeqConfig)
+
+type writerSeqConfig struct {
+	size  int
+	delim []byte
+}
+
+// WithChunkSize sets the maximum size of chunks yielded by WriterSeq, 32KiB by default.
+// It will panic if size is not positive.
+func WithChunkSize(size int) WriterSeqOption {
+	if size <= 0 {
+		panic("itermore.WithChunkSize: size must be positive")
+	}
+
+	return func(cfg *writerSeqConfig) {
+		cfg.size = size
+	}
+}
+
+// WithChunkDelim makes WriterSeq yield a chunk each time the delimiter is written.
+// The delimiter is included at the end of the chunk.
+// Data without delimiters is still split into chunks of the maximum size.
+// It will panic if delim is empty.
+func WithChunkDelim(delim []byte) WriterSeqOption {
+	if len(delim) == 0 {
+		panic("itermore.WithChunkDelim: empty delimiter")
+	}
+
+	delim = bytes.Clone(delim)
+
+	return func(cfg *writerSeqConfig) {
+		cfg.delim = delim
+	}
+}
+
+// WriterSeq returns an io.WriteCloser, which delivers written data to the consume function as a sequence of chunks.
+// It is an inverse of MultiReader and SeqReader: it allows to use sequence consumers under writers,
+// like gzip.Writer or json.Encoder.
+//
+// The consume function runs in a separate goroutine, which is started immediately.
+// Writes block until the consumer processes the chunk, which provides backpressure.
+// Chunks share the writer buffer and are valid until the next iteration.
+//
+// If consume returns an error or panics, pending and following writes return this error (panics are reported as *PanicError).
+// If consume returns before the sequence is drained, following writes return io.ErrClosedPipe.
+// Close flushes buffered data, finishes the sequence, waits for consume to return and reports its error.
+func WriterSeq(consume func(chunks iter.Seq[[]byte]) error, opts ...WriterSeqOption) io.WriteCloser {
+	cfg := &writerSeqConfig{
+		size: defaultBufferSize,
+	}
+
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	ws := &writerSeq{
+		cfg:    cfg,
+		chunks: make(chan []byte),
+		acks:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	go ws.run(consume)
+
+	return ws
+}
+
+type writerSeq struct {
+	cfg *writerSeqConfig
+	buf []byte
+	// scanned is the length of buf prefix, which is known to contain no delimiter.
+	scanned int
+
+	chunks chan []byte
+	acks   chan struct{}
+	done   chan struct{}
+	// err is the consumer error, it is valid after done is closed.
+	err error
+
+	closed   bool
+	closeErr error
+}
+
+func (ws *writerSeq) run(consume func(chunks iter.Seq[[]byte]) error) {
+	defer close(ws.done)
+	defer func() {
+		if p := recover(); p != nil {
+			ws.err = newPanicError(p)
+		}
+	}()
+
+	ws.err = consume(func(yield func([]byte) bool) {
+		for chunk := range ws.chunks {
+			ok := yield(chunk)
+			ws.acks <- struct{}{}
+
+			if !ok {
+				return
+			}
+		}
+	})
+}
+
+func (ws *writerSeq) Write(p []byte) (int, error) {
+	if ws.closed {
+		return 0, io.ErrClosedPipe
+	}
+
+	written := 0
+	for len(p) > 0 {
+		n := min(ws.cfg.size-len(ws.buf), len(p))
+		ws.buf = append(ws.buf, p[:n]...)
+		p = p[n:]
+
+		if err := ws.flushDelimited(); err != nil {
+			return written, err
+		}
+
+		if len(ws.buf) >= ws.cfg.size {
+			if err := ws.flush(len(ws.buf)); err != nil {
+				return written, err
+			}
+		}
+
+		written += n
+	}
+
+	return written, nil
+}
+
+// flushDelimited emits all chunks, which end with the delimiter.
+func (ws *writerSeq) flushDelimited() error {
+	delim := ws.cfg.delim
+	if len(delim) == 0 {
+		return nil
+	}
+
+	for {
+		i := bytes.Index(ws.buf[ws.scanned:], delim)
+		if i < 0 {
+			break
+		}
+
+		if err := ws.flush(ws.scanned + i + len(delim)); err != nil {
+			return err
+		}
+	}
+
+	// delimiter can start in the tail of the buffer
+	ws.scanned = max(0, len(ws.buf)-len(delim)+1)
+
+	return nil
+}
+
+// flush emits first n bytes of the buffer as a chunk.
+func (ws *writerSeq) flush(n int) error {
+	if err := ws.emit(ws.buf[:n]); err != nil {
+		return err
+	}
+
+	rest := copy(ws.buf, ws.buf[n:])
+	ws.buf = ws.buf[:rest]
+	ws.scanned = 0
+
+	return nil
+}
+
+func (ws *writerSeq) emit(chunk []byte) error {
+	select {
+	case ws.chunks <- chunk:
+		// pass
+	case <-ws.done:
+		return ws.consumerErr()
+	}
+
+	select {
+	case <-ws.acks:
+		return nil
+	case <-ws.done:
+		// consumer has panicked while processing the chunk
+		return ws.consumerErr()
+	}
+}
+
+func (ws *writerSeq) consumerErr() error {
+	if ws.err != nil {
+		return ws.err
+	}
+
+	return io.ErrClosedPipe
+}
+
+// Close flushes buffered data and waits for the consumer to return.
+func (ws *writerSeq) Close() error {
+	if ws.closed {
+		return ws.closeErr
+	}
+	ws.closed = true
+
+	var flushErr error
+	if len(ws.buf) > 0 {
+		flushErr = ws.flush(len(ws.buf))
+	}
+
+	close(ws.chunks)
+	<-ws.done
+
+	ws.closeErr = ws.err
+	if ws.closeErr == nil {
+		ws.closeErr = flushErr
+	}
+
+	return ws.closeErr
+}
@@ -0,0 +1,207 @@
+package itermore_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ninedraft/itermore"
+)
+
+func ExampleWriterSeq() {
+	wr := itermore.WriterSeq(func(chunks iter.Seq[[]byte]) error {
+		for chunk := range chunks {
+			fmt.Printf("%q\n", chunk)
+		}
+		return nil
+	}, itermore.WithChunkDelim([]byte("\n")))
+
+	_, _ = io.WriteString(wr, "first\nsec")
+	_, _ = io.WriteString(wr, "ond\nthird")
+	_ = wr.Close()
+
+	// Output: "first\n"
+	// "second\n"
+	// "third"
+}
+
+// collectChunks returns a consumer, which collects chunks into the given slice.
+func collectChunks(dst *[]string) func(iter.Seq[[]byte]) error {
+	return func(chunks iter.Seq[[]byte]) error {
+		for chunk := range chunks {
+			*dst = append(*dst, string(chunk))
+		}
+		return nil
+	}
+}
+
+func TestWriterSeq(t *testing.T) {
+	t.Parallel()
+
+	t.Run("size", func(t *testing.T) {
+		t.Parallel()
+
+		var got []string
+		wr := itermore.WriterSeq(collectChunks(&got), itermore.WithChunkSize(4))
+
+		for _, s := range []string{"ab", "cdefghij", "k"} {
+			if _, err := io.WriteString(wr, s); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := wr.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"abcd", "efgh", "ijk"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+	})
+
+	t.Run("delim", func(t *testing.T) {
+		t.Parallel()
+
+		var got []string
+		wr := itermore.WriterSeq(collectChunks(&got),
+			itermore.WithChunkDelim([]byte("<>")),
+			itermore.WithChunkSize(8),
+		)
+
+		for _, s := range []string{"a<", ">bb<><", ">", "cccccccccc<>d"} {
+			if _, err := io.WriteString(wr, s); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err := wr.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		want := []string{"a<>", "bb<>", "<>", "cccccccc", "cc<>", "d"}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %q", got)
+			t.Errorf("want: %q", want)
+		}
+	})
+
+	t.Run("gzip", func(t *testing.T) {
+		t.Parallel()
+
+		compressed := &bytes.Buffer{}
+		wr := itermore.WriterSeq(func(chunks iter.Seq[[]byte]) error {
+			_, err := itermore.CollectJoinBytes(compressed, chunks, nil)
+			return err
+		})
+
+		input := strings.Repeat("hello, world\n", 10_000)
+
+		zw := gzip.NewWriter(wr)
+		if _, err := io.WriteString(zw, input); err != nil {
+			t.Fatal(err)
+		}
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := wr.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		zr, err := gzip.NewReader(compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := io.ReadAll(zr)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(got) != input {
+			t.Errorf("decompressed data differs from input")
+		}
+	})
+
+	t.Run("consumer-error", func(t *testing.T) {
+		t.Parallel()
+
+		errTest := errors.New("test error")
+		wr := itermore.WriterSeq(func(chunks iter.Seq[[]byte]) error {
+			for range chunks {
+				return errTest
+			}
+			return nil
+		}, itermore.WithChunkSize(2))
+
+		_, err := io.WriteString(wr, "abcdef")
+		if !errors.Is(err, errTest) {
+			t.Fatalf("write: got %v, want %v", err, errTest)
+		}
+
+		if err := wr.Close(); !errors.Is(err, errTest) {
+			t.Fatalf("close: got %v, want %v", err, errTest)
+		}
+	})
+
+	t.Run("consumer-stop", func(t *testing.T) {
+		t.Parallel()
+
+		wr := itermore.WriterSeq(func(chunks iter.Seq[[]byte]) error {
+			for range chunks {
+				break
+			}
+			return nil
+		}, itermore.WithChunkSize(2))
+
+		_, err := io.WriteString(wr, "abcdef")
+		if !errors.Is(err, io.ErrClosedPipe) {
+			t.Fatalf("got %v, want %v", err, io.ErrClosedPipe)
+		}
+		_ = wr.Close()
+	})
+
+	t.Run("consumer-panic", func(t *testing.T) {
+		t.Parallel()
+
+		wr := itermore.WriterSeq(func(chunks iter.Seq[[]byte]) error {
+			for range chunks {
+				panic("test panic")
+			}
+			return nil
+		}, itermore.WithChunkSize(2))
+
+		_, err := io.WriteString(wr, "abcdef")
+
+		var pe *itermore.PanicError
+		if !errors.As(err, &pe) {
+			t.Fatalf("got %v, want *itermore.PanicError", err)
+		}
+		_ = wr.Close()
+	})
+
+	t.Run("write-after-close", func(t *testing.T) {
+		t.Parallel()
+
+		var got []string
+		wr := itermore.WriterSeq(collectChunks(&got))
+		if err := wr.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := wr.Write([]byte("a")); !errors.Is(err, io.ErrClosedPipe) {
+			t.Fatalf("got %v, want %v", err, io.ErrClosedPipe)
+		}
+
+		if len(got) != 0 {
+			t.Errorf("got %q, want no chunks", got)
+		}
+	})
+}
@@ -1,6 +1,10 @@
 package itermore
 
-import "iter"
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
 
 // Zip creates a sequence that yields pairs of values from the given sequences.
 // If any of the sequences is stopped, the sequence will stop.
@@ -52,3 +56,311 @@ func ZipLongest[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
 		}
 	}
 }
+
+// Option is a value, which may be absent.
+type Option[E any] struct {
+	Value E
+	Ok    bool
+}
+
+// Some creates a present Option.
+func Some[E any](value E) Option[E] {
+	return Option[E]{Value: value, Ok: true}
+}
+
+// Get returns the value and a flag, which reports whether the value is present.
+func (o Option[E]) Get() (E, bool) {
+	return o.Value, o.Ok
+}
+
+// Or returns the value if it is present, otherwise it returns fallback.
+func (o Option[E]) Or(fallback E) E {
+	if o.Ok {
+		return o.Value
+	}
+	return fallback
+}
+
+// ZipOptional creates a sequence that yields pairs of optional values from the given sequences.
+// If any of the sequences is stopped, the sequence will continue to emit absent values for that sequence.
+// If both sequences are stopped, the sequence will stop.
+// Unlike ZipLongest, it allows to distinguish zero values from padding.
+func ZipOptional[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[Option[A], Option[B]] {
+	return func(yield func(Option[A], Option[B]) bool) {
+		nextA, cancelA := iter.Pull(a)
+		defer cancelA()
+
+		nextB, cancelB := iter.Pull(b)
+		defer cancelB()
+
+		for {
+			av, okA := nextA()
+			bv, okB := nextB()
+
+			if !okA && !okB {
+				break
+			}
+
+			if !yield(Option[A]{av, okA}, Option[B]{bv, okB}) {
+				break
+			}
+		}
+	}
+}
+
+// ZipLongestFill creates a sequence that yields pairs of values from the given sequences.
+// If any of the sequences is stopped, the sequence will continue to emit fill value for that sequence.
+// If both sequences are stopped, the sequence will stop.
+func ZipLongestFill[A, B any](a iter.Seq[A], b iter.Seq[B], fillA A, fillB B) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		for av, bv := range ZipOptional(a, b) {
+			if !yield(av.Or(fillA), bv.Or(fillB)) {
+				return
+			}
+		}
+	}
+}
+
+// ErrLengthMismatch is reported by ZipStrict when sequences have different lengths.
+var ErrLengthMismatch = errors.New("sequences have different lengths")
+
+// ZipStrict creates a sequence that yields pairs of values from the given sequences.
+// Sequences are expected to have equal lengths: if one of the sequences is stopped before the other,
+// ZipStrict panics with an error wrapping ErrLengthMismatch.
+// Pairs preceding the mismatch are yielded as usual.
+func ZipStrict[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		var n int
+		for av, bv := range ZipOptional(a, b) {
+			if !av.Ok || !bv.Ok {
+				stopped := "first"
+				if av.Ok {
+					stopped = "second"
+				}
+				panic(fmt.Errorf("itermore.ZipStrict: %w: %s sequence stopped after %d values",
+					ErrLengthMismatch, stopped, n))
+			}
+
+			if !yield(av.Value, bv.Value) {
+				return
+			}
+			n++
+		}
+	}
+}
+
+// Tuple3 is a group of three values.
+type Tuple3[A, B, C any] struct {
+	A A
+	B B
+	C C
+}
+
+// Tuple4 is a group of four values.
+type Tuple4[A, B, C, D any] struct {
+	A A
+	B B
+	C C
+	D D
+}
+
+// Tuple5 is a group of five values.
+type Tuple5[A, B, C, D, E any] struct {
+	A A
+	B B
+	C C
+	D D
+	E E
+}
+
+// Zip3 creates a sequence that yields tuples of values from the given sequences.
+// If any of the sequences is stopped, the sequence will stop.
+func Zip3[A, B, C any](a iter.Seq[A], b iter.Seq[B], c iter.Seq[C]) iter.Seq[Tuple3[A, B, C]] {
+	return func(yield func(Tuple3[A, B, C]) bool) {
+		nextA, cancelA := iter.Pull(a)
+		defer cancelA()
+
+		nextB, cancelB := iter.Pull(b)
+		defer cancelB()
+
+		nextC, cancelC := iter.Pull(c)
+		defer cancelC()
+
+		for {
+			av, okA := nextA()
+			bv, okB := nextB()
+			cv, okC := nextC()
+
+			if !okA || !okB || !okC {
+				break
+			}
+
+			if !yield(Tuple3[A, B, C]{av, bv, cv}) {
+				break
+			}
+		}
+	}
+}
+
+// Zip4 creates a sequence that yields tuples of values from the given sequences.
+// If any of the sequences is stopped, the sequence will stop.
+func Zip4[A, B, C, D any](a iter.Seq[A], b iter.Seq[B], c iter.Seq[C], d iter.Seq[D]) iter.Seq[Tuple4[A, B, C, D]] {
+	return func(yield func(Tuple4[A, B, C, D]) bool) {
+		nextA, cancelA := iter.Pull(a)
+		defer cancelA()
+
+		nextB, cancelB := iter.Pull(b)
+		defer cancelB()
+
+		nextC, cancelC := iter.Pull(c)
+		defer cancelC()
+
+		nextD, cancelD := iter.Pull(d)
+		defer cancelD()
+
+		for {
+			av, okA := nextA()
+			bv, okB := nextB()
+			cv, okC := nextC()
+			dv, okD := nextD()
+
+			if !okA || !okB || !okC || !okD {
+				break
+			}
+
+			if !yield(Tuple4[A, B, C, D]{av, bv, cv, dv}) {
+				break
+			}
+		}
+	}
+}
+
+// Zip5 creates a sequence that yields tuples of values from the given sequences.
+// If any of the sequences is stopped, the sequence will stop.
+func Zip5[A, B, C, D, E any](a iter.Seq[A], b iter.Seq[B], c iter.Seq[C], d iter.Seq[D], e iter.Seq[E]) iter.Seq[Tuple5[A, B, C, D, E]] {
+	return func(yield func(Tuple5[A, B, C, D, E]) bool) {
+		nextA, cancelA := iter.Pull(a)
+		defer cancelA()
+
+		nextB, cancelB := iter.Pull(b)
+		defer cancelB()
+
+		nextC, cancelC := iter.Pull(c)
+		defer cancelC()
+
+		nextD, cancelD := iter.Pull(d)
+		defer cancelD()
+
+		nextE, cancelE := iter.Pull(e)
+		defer cancelE()
+
+		for {
+			av, okA := nextA()
+			bv, okB := nextB()
+			cv, okC := nextC()
+			dv, okD := nextD()
+			ev, okE := nextE()
+
+			if !okA || !okB || !okC || !okD || !okE {
+				break
+			}
+
+			if !yield(Tuple5[A, B, C, D, E]{av, bv, cv, dv, ev}) {
+				break
+			}
+		}
+	}
+}
+
+// ZipSlices creates a sequence that yields rows of values from the given sequences.
+// Each row contains one value from each sequence in the order of seqs.
+// A new slice is allocated for each row, so it can be retained by the caller.
+// If any of the sequences is stopped, the sequence will stop.
+// If seqs is empty, the sequence will be empty.
+func ZipSlices[E any](seqs []iter.Seq[E]) iter.Seq[[]E] {
+	return func(yield func([]E) bool) {
+		if len(seqs) == 0 {
+			return
+		}
+
+		nexts := make([]func() (E, bool), 0, len(seqs))
+		for _, seq := range seqs {
+			next, cancel := iter.Pull(seq)
+			defer cancel()
+
+			nexts = append(nexts, next)
+		}
+
+		for {
+			row := make([]E, len(nexts))
+			for i, next := range nexts {
+				value, ok := next()
+				if !ok {
+					return
+				}
+				row[i] = value
+			}
+
+			if !yield(row) {
+				return
+			}
+		}
+	}
+}
+
+// ZipWith creates a sequence that yields results of fn applied to pairs of values from the given sequences.
+// If any of the sequences is stopped, the sequence will stop.
+func ZipWith[A, B, R any](a iter.Seq[A], b iter.Seq[B], fn func(A, B) R) iter.Seq[R] {
+	return func(yield func(R) bool) {
+		for av, bv := range Zip(a, b) {
+			if !yield(fn(av, bv)) {
+				return
+			}
+		}
+	}
+}
+
+// Unzip splits a sequence into two sequences using the given function.
+// The source sequence is iterated only once and split is called once per value,
+// split results are buffered until both sequences yield them.
+// See Tee for details on buffering and lifetime of returned sequences.
+//
+// Both returned sequences must be ranged, otherwise the source is never stopped.
+// If only one of them is needed, release the other one with an empty loop: for range seq { break }.
+func Unzip[E, A, B any](seq iter.Seq[E], split func(E) (A, B)) (iter.Seq[A], iter.Seq[B]) {
+	pairs := func(yield func(Pair[A, B]) bool) {
+		for value := range seq {
+			if !yield(PairOf(split(value))) {
+				return
+			}
+		}
+	}
+
+	seqs := Tee(pairs, 2)
+
+	left := func(yield func(A) bool) {
+		for pair := range seqs[0] {
+			if !yield(pair.A) {
+				return
+			}
+		}
+	}
+
+	right := func(yield func(B) bool) {
+		for pair := range seqs[1] {
+			if !yield(pair.B) {
+				return
+			}
+		}
+	}
+
+	return left, right
+}
+
+// Unzip2 splits a sequence of pairs into a sequence of first and a sequence of second values.
+// It is an inverse of Zip.
+// The source sequence is iterated only once, values are buffered until both sequences yield them.
+// Both returned sequences must be ranged, see Unzip for details.
+func Unzip2[A, B any](seq iter.Seq2[A, B]) (iter.Seq[A], iter.Seq[B]) {
+	return Unzip(ToPairs(seq), Pair[A, B].Values)
+}
@@ -1,8 +1,11 @@
 package itermore_test
 
 import (
+	"errors"
 	"fmt"
+	"iter"
 	"slices"
+	"strings"
 	"testing"
 
 	"github.com/ninedraft/itermore"
@@ -122,3 +125,321 @@ func TestZipLongest(t *testing.T) {
 		}
 	})
 }
+
+func ExampleZip3() {
+	xx := itermore.Items(1, 2, 3)
+	yy := itermore.Items("a", "b", "c")
+	zz := itermore.Items(true, false)
+
+	for t := range itermore.Zip3(xx, yy, zz) {
+		fmt.Println(t.A, t.B, t.C)
+	}
+	// Output: 1 a true
+	// 2 b false
+}
+
+func TestZipN(t *testing.T) {
+	t.Parallel()
+
+	ints := func() iter.Seq[int] { return itermore.Items(1, 2, 3) }
+	strs := func() iter.Seq[string] { return itermore.Items("a", "b", "c", "d") }
+
+	assertBreak(t, itermore.Zip3(ints(), strs(), ints()))
+	assertBreak(t, itermore.Zip4(ints(), strs(), ints(), strs()))
+	assertBreak(t, itermore.Zip5(ints(), strs(), ints(), strs(), ints()))
+
+	t.Run("zip3", func(t *testing.T) {
+		t.Parallel()
+
+		got := slices.Collect(itermore.Zip3(ints(), strs(), itermore.Items(1.5, 2.5)))
+		want := []itermore.Tuple3[int, string, float64]{
+			{1, "a", 1.5}, {2, "b", 2.5},
+		}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("zip4", func(t *testing.T) {
+		t.Parallel()
+
+		got := slices.Collect(itermore.Zip4(ints(), strs(), ints(), strs()))
+		want := []itermore.Tuple4[int, string, int, string]{
+			{1, "a", 1, "a"}, {2, "b", 2, "b"}, {3, "c", 3, "c"},
+		}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("zip5", func(t *testing.T) {
+		t.Parallel()
+
+		got := slices.Collect(itermore.Zip5(ints(), strs(), ints(), strs(), itermore.None[int]))
+		if len(got) != 0 {
+			t.Errorf("got %v, want empty", got)
+		}
+	})
+}
+
+func TestZipSlices(t *testing.T) {
+	t.Parallel()
+
+	newSeqs := func() []iter.Seq[int] {
+		return []iter.Seq[int]{
+			itermore.Items(1, 2, 3),
+			itermore.Items(10, 20, 30, 40),
+			itermore.Items(100, 200, 300),
+		}
+	}
+
+	assertBreak(t, itermore.ZipSlices(newSeqs()))
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		got := slices.Collect(itermore.ZipSlices(newSeqs()))
+		want := [][]int{{1, 10, 100}, {2, 20, 200}, {3, 30, 300}}
+
+		if !slices.EqualFunc(got, want, slices.Equal) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		for row := range itermore.ZipSlices[int](nil) {
+			t.Fatalf("must not iterate over empty seq, got: %v", row)
+		}
+	})
+}
+
+func TestZipWith(t *testing.T) {
+	t.Parallel()
+
+	add := func(a, b int) int { return a + b }
+
+	assertBreak(t, itermore.ZipWith(itermore.Items(1), itermore.Items(2), add))
+
+	got := slices.Collect(itermore.ZipWith(itermore.Items(1, 2, 3), itermore.Items(10, 20), add))
+	want := []int{11, 22}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("got:  %v", got)
+		t.Errorf("want: %v", want)
+	}
+}
+
+func TestUnzip2(t *testing.T) {
+	t.Parallel()
+
+	{
+		a, b := itermore.Unzip2(itermore.Zip(itermore.Items(1), itermore.Items("a")))
+		assertBreak(t, a)
+		assertBreak(t, b)
+	}
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		pulled := 0
+		source := func(yield func(int, string) bool) {
+			for i, s := range itermore.Enumerate(itermore.Items("a", "b", "c")) {
+				pulled++
+				if !yield(i, s) {
+					return
+				}
+			}
+		}
+
+		ints, strs := itermore.Unzip2(source)
+
+		gotStrs := slices.Collect(strs)
+		gotInts := slices.Collect(ints)
+
+		if want := []string{"a", "b", "c"}; !slices.Equal(gotStrs, want) {
+			t.Errorf("got:  %v", gotStrs)
+			t.Errorf("want: %v", want)
+		}
+
+		if want := []int{0, 1, 2}; !slices.Equal(gotInts, want) {
+			t.Errorf("got:  %v", gotInts)
+			t.Errorf("want: %v", want)
+		}
+
+		if pulled != 3 {
+			t.Errorf("source must be iterated once, pulled %d values", pulled)
+		}
+	})
+}
+
+func TestUnzip(t *testing.T) {
+	t.Parallel()
+
+	split := func(s string) (string, string) {
+		k, v, _ := strings.Cut(s, "=")
+		return k, v
+	}
+
+	t.Run("iter", func(t *testing.T) {
+		t.Parallel()
+
+		calls := 0
+		keys, values := itermore.Unzip(itermore.Items("a=1", "b=2"), func(s string) (string, string) {
+			calls++
+			return split(s)
+		})
+
+		if got, want := slices.Collect(keys), []string{"a", "b"}; !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+
+		if got, want := slices.Collect(values), []string{"1", "2"}; !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+
+		if calls != 2 {
+			t.Errorf("split must be called once per value, called %d times", calls)
+		}
+	})
+
+	t.Run("release", func(t *testing.T) {
+		t.Parallel()
+
+		stopped := false
+		source := func(yield func(string) bool) {
+			defer func() { stopped = true }()
+			for _, s := range []string{"a=1", "b=2", "c=3"} {
+				if !yield(s) {
+					return
+				}
+			}
+		}
+
+		keys, values := itermore.Unzip(source, split)
+
+		for key := range keys {
+			if key == "a" {
+				break
+			}
+		}
+
+		if stopped {
+			t.Fatal("source must not be stopped, while values are not ranged")
+		}
+
+		for range values {
+			break
+		}
+
+		if !stopped {
+			t.Error("source must be stopped after both sequences are ranged")
+		}
+	})
+}
+
+func ExampleZipOptional() {
+	xx := itermore.Items(0, 1)
+	yy := itermore.Items("a")
+
+	for x, y := range itermore.ZipOptional(xx, yy) {
+		fmt.Println(x.Value, x.Ok, y.Value, y.Ok)
+	}
+	// Output: 0 true a true
+	// 1 true  false
+}
+
+func TestZipOptional(t *testing.T) {
+	t.Parallel()
+
+	assertBreak2(t, itermore.ZipOptional(itermore.Items(1), itermore.Items("a")))
+
+	type optPair struct {
+		a itermore.Option[int]
+		b itermore.Option[string]
+	}
+
+	got := []optPair{}
+	for a, b := range itermore.ZipOptional(itermore.Items(0, 0), itermore.Items("", "", "c")) {
+		got = append(got, optPair{a, b})
+	}
+
+	want := []optPair{
+		{itermore.Some(0), itermore.Some("")},
+		{itermore.Some(0), itermore.Some("")},
+		{itermore.Option[int]{}, itermore.Some("c")},
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("got:  %v", got)
+		t.Errorf("want: %v", want)
+	}
+}
+
+func TestZipLongestFill(t *testing.T) {
+	t.Parallel()
+
+	assertBreak2(t, itermore.ZipLongestFill(itermore.Items(1), itermore.Items("a"), -1, "-"))
+
+	got := []pair{}
+	for a, b := range itermore.ZipLongestFill(itermore.Items(1, 2, 3), itermore.Items("a"), -1, "-") {
+		got = append(got, pair{a, b})
+	}
+
+	want := []pair{{1, "a"}, {2, "-"}, {3, "-"}}
+	if !slices.Equal(got, want) {
+		t.Errorf("got:  %v", got)
+		t.Errorf("want: %v", want)
+	}
+}
+
+func TestZipStrict(t *testing.T) {
+	t.Parallel()
+
+	assertBreak2(t, itermore.ZipStrict(itermore.Items(1, 2), itermore.Items("a", "b")))
+
+	t.Run("equal", func(t *testing.T) {
+		t.Parallel()
+
+		got := []pair{}
+		for a, b := range itermore.ZipStrict(itermore.Items(1, 2), itermore.Items("a", "b")) {
+			got = append(got, pair{a, b})
+		}
+
+		want := []pair{{1, "a"}, {2, "b"}}
+		if !slices.Equal(got, want) {
+			t.Errorf("got:  %v", got)
+			t.Errorf("want: %v", want)
+		}
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		got := []pair{}
+		defer func() {
+			err, _ := recover().(error)
+			if !errors.Is(err, itermore.ErrLengthMismatch) {
+				t.Fatalf("got %v, want %v", err, itermore.ErrLengthMismatch)
+			}
+
+			want := []pair{{1, "a"}}
+			if !slices.Equal(got, want) {
+				t.Errorf("got:  %v", got)
+				t.Errorf("want: %v", want)
+			}
+		}()
+
+		for a, b := range itermore.ZipStrict(itermore.Items(1), itermore.Items("a", "b")) {
+			got = append(got, pair{a, b})
+		}
+	})
+}